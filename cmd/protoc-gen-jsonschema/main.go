@@ -2,25 +2,73 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/TheRebelOfBabylon/protoc-gen-jsonschema/config"
 	"github.com/TheRebelOfBabylon/protoc-gen-jsonschema/generator"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 var flags flag.FlagSet
 
 func main() {
-	cfg := &config.Config{
-		EnumType: flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
-		RepeatedDefs: flags.Bool("repeated_defs", true, `repeat definitions. If "true", repeats definitions across all files`),
-	}
+	cfg := config.NewConfig(&flags)
 
 	opts := protogen.Options{
 		ParamFunc: flags.Set,
 	}
 
-	opts.Run(func(plugin *protogen.Plugin) error {
+	err := run(opts, func(plugin *protogen.Plugin) error {
 		return generator.NewJSONSchemaGenerator(plugin, cfg).Run()
 	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
+		os.Exit(1)
+	}
+}
+
+// run works like protogen.Options.Run, except that the paths parameter is set on the flags instead of
+// being handled by protogen, which only knows the layouts of generated Go code
+func run(opts protogen.Options, f func(*protogen.Plugin) error) error {
+	if len(os.Args) > 1 {
+		return fmt.Errorf("unknown argument %q (this program should be run by protoc, not directly)", os.Args[1])
+	}
+	in, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+	req := &pluginpb.CodeGeneratorRequest{}
+	if err := proto.Unmarshal(in, req); err != nil {
+		return err
+	}
+	params := []string{}
+	for _, param := range strings.Split(req.GetParameter(), ",") {
+		if value, ok := strings.CutPrefix(param, "paths="); ok {
+			if err := flags.Set("paths", value); err != nil {
+				return err
+			}
+			continue
+		}
+		params = append(params, param)
+	}
+	req.Parameter = proto.String(strings.Join(params, ","))
+	plugin, err := opts.New(req)
+	if err != nil {
+		return err
+	}
+	if err := f(plugin); err != nil {
+		plugin.Error(err)
+	}
+	out, err := proto.Marshal(plugin.Response())
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
 }
\ No newline at end of file
@@ -1,6 +1,42 @@
 package config
 
+import "flag"
+
 type Config struct {
-	EnumType     *string
-	RepeatedDefs *bool
-}
\ No newline at end of file
+	EnumType       *string
+	RepeatedDefs   *bool
+	Strict         *bool
+	Deprecated     *string
+	Draft          *string
+	Output         *string
+	OutputFormat   *string
+	Bundle         *string
+	Paths          *string
+	FileTemplate   *string
+	PropertyOrder  *string
+	Services       *bool
+	IdBase         *string
+	IdTemplate     *string
+	IncludeImports *string
+}
+
+// NewConfig creates a Config struct whose values are set by the parameters of the given flag set
+func NewConfig(flags *flag.FlagSet) *Config {
+	return &Config{
+		EnumType:       flags.String("enum_type", "integer", `type for enum serialization. Use "string" for string-based serialization`),
+		RepeatedDefs:   flags.Bool("repeated_defs", true, `repeat definitions. If "true", repeats definitions across all files`),
+		Strict:         flags.Bool("strict", false, `strict mode. If "true", objects generated from messages forbid additional properties`),
+		Draft:          flags.String("draft", "07", `JSON schema draft of generated schemas. Use "04", "07", "2019-09" or "2020-12"`),
+		Output:         flags.String("output", "jsonschema", `output mode. Use "openapi31", "openapi30" or "swagger20" to generate one OpenAPI document per proto package, or "asyncapi" to generate one AsyncAPI document per proto package from streaming methods`),
+		OutputFormat:   flags.String("output_format", "json", `format of generated files. Use "yaml" or "yml" to write YAML files with that extension`),
+		Bundle:         flags.String("bundle", "", `bundle schemas. Use "file" or "package" to generate one schema per proto file or package with every message in its definitions`),
+		Paths:          flags.String("paths", "flat", `layout of generated files. Use "source_relative" to mirror the proto files or "package" to use the proto packages as directories`),
+		FileTemplate:   flags.String("file_template", "", `name of generated files, where {name} is replaced by the schema name. Defaults to "{name}" with the extension of the output format`),
+		PropertyOrder:  flags.String("property_order", "declaration", `order of properties and required properties. Use "number" to order them by field number`),
+		Services:       flags.Bool("services", false, `service schemas. If "true", generates a schema per method with its request and response, and an index per service`),
+		IdBase:         flags.String("id_base", "", `base URI of schema ids, ending with a slash, e.g. "https://schemas.example.com/". The file option id_base overrides it`),
+		IdTemplate:     flags.String("id_template", "", `template of schema ids below the base URI, using {package}, {name}, {full_name}, {file} and {version}`),
+		IncludeImports: flags.String("include_imports", "", `schemas of imported files. Use "referenced" to also generate the messages of imported files which generated messages reference, or "all" to also generate every message of the files which generated files import. google/protobuf files keep their well-known type mappings, and files declaring custom options are only generated when referenced`),
+		Deprecated:     flags.String("deprecated", "annotate", `handling of deprecated elements. Use "omit" to drop deprecated fields and enum values`),
+	}
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// openAPIKeywords holds the keywords read from the OpenAPI annotations of grpc-gateway or gnostic, which both
+// describe schemas with the same subset of JSON schema
+type openAPIKeywords struct {
+	Title            string
+	Description      string
+	Default          interface{}
+	Example          interface{}
+	ReadOnly         bool
+	WriteOnly        bool
+	Deprecated       bool
+	Nullable         bool
+	MultipleOf       float64
+	Maximum          float64
+	ExclusiveMaximum bool
+	Minimum          float64
+	ExclusiveMinimum bool
+	MaxLength        int64
+	MinLength        int64
+	Pattern          string
+	MaxItems         int64
+	MinItems         int64
+	UniqueItems      bool
+	MaxProperties    int64
+	MinProperties    int64
+	Required         []string
+	Enum             []interface{}
+	Format           string
+	Extensions       map[string]interface{}
+}
+
+// applyOpenAPIProperty sets the keywords read from an OpenAPI annotation of a field on the SchemaProperty
+func (g *JSONSchemaGenerator) applyOpenAPIProperty(keywords *openAPIKeywords, propertySchema *SchemaProperty) {
+	if keywords.Title != "" {
+		propertySchema.Title = keywords.Title
+	}
+	if keywords.Description != "" {
+		propertySchema.Description = keywords.Description
+	}
+	if keywords.Default != nil {
+		propertySchema.Default = keywords.Default
+	}
+	if keywords.Example != nil {
+		propertySchema.Examples = []interface{}{keywords.Example}
+	}
+	if keywords.ReadOnly {
+		propertySchema.ReadOnly = true
+	}
+	if keywords.WriteOnly {
+		propertySchema.WriteOnly = true
+	}
+	if keywords.Deprecated {
+		propertySchema.Deprecated = true
+	}
+	if keywords.Nullable {
+		propertySchema.Nullable = true
+	}
+	if keywords.MultipleOf != 0 {
+		propertySchema.MultipleOf = keywords.MultipleOf
+	}
+	if keywords.Maximum != 0 || keywords.ExclusiveMaximum {
+		if keywords.ExclusiveMaximum {
+			propertySchema.ExclusiveMaximum = keywords.Maximum
+		} else {
+			propertySchema.Maximum = keywords.Maximum
+		}
+	}
+	if keywords.Minimum != 0 || keywords.ExclusiveMinimum {
+		if keywords.ExclusiveMinimum {
+			propertySchema.ExclusiveMinimum = keywords.Minimum
+		} else {
+			propertySchema.Minimum = keywords.Minimum
+		}
+	}
+	if keywords.MaxLength != 0 {
+		propertySchema.MaxLength = int32(keywords.MaxLength)
+	}
+	if keywords.MinLength != 0 {
+		propertySchema.MinLength = int32(keywords.MinLength)
+	}
+	if keywords.Pattern != "" {
+		propertySchema.Pattern = keywords.Pattern
+	}
+	if keywords.MaxItems != 0 {
+		propertySchema.MaxItems = int32(keywords.MaxItems)
+	}
+	if keywords.MinItems != 0 {
+		propertySchema.MinItems = int32(keywords.MinItems)
+	}
+	if keywords.UniqueItems {
+		propertySchema.UniqueItems = true
+	}
+	if keywords.MaxProperties != 0 {
+		propertySchema.MaxProperties = int32(keywords.MaxProperties)
+	}
+	if keywords.MinProperties != 0 {
+		propertySchema.MinProperties = int32(keywords.MinProperties)
+	}
+	if len(keywords.Enum) > 0 {
+		propertySchema.Enum = keywords.Enum
+	}
+	// the format derived from the field type is more accurate
+	if keywords.Format != "" && propertySchema.Format == "" {
+		propertySchema.Format = keywords.Format
+	}
+	propertySchema.Extra = mergeExtensions(keywords.Extensions, propertySchema.Extra)
+}
+
+// applyOpenAPISchema sets the keywords read from an OpenAPI annotation of a message on the Schema. The required fields it lists must have a property in the Schema
+func (g *JSONSchemaGenerator) applyOpenAPISchema(keywords *openAPIKeywords, message *protogen.Message, schema *Schema) error {
+	if keywords.Title != "" {
+		schema.Title = keywords.Title
+	}
+	if keywords.Description != "" {
+		schema.Description = keywords.Description
+	}
+	if keywords.Example != nil {
+		schema.Examples = []interface{}{keywords.Example}
+	}
+	// required fields may be listed with their proto names
+	required, err := g.lookupPropertyNames(message, schema, keywords.Required)
+	if err != nil {
+		return fmt.Errorf("invalid required fields of message %s: %w", message.Desc.FullName(), err)
+	}
+	for _, name := range required {
+		schema.Required = appendUnique(schema.Required, name)
+	}
+	if keywords.MaxProperties != 0 {
+		schema.MaxProperties = int32(keywords.MaxProperties)
+	}
+	if keywords.MinProperties != 0 {
+		schema.MinProperties = int32(keywords.MinProperties)
+	}
+	schema.Extra = mergeExtensions(keywords.Extensions, schema.Extra)
+	return nil
+}
+
+// mergeExtensions merges the extensions of an OpenAPI annotation into keywords
+func mergeExtensions(extensions map[string]interface{}, keywords map[string]interface{}) map[string]interface{} {
+	for name, value := range extensions {
+		if keywords == nil {
+			keywords = make(map[string]interface{})
+		}
+		keywords[name] = value
+	}
+	return keywords
+}
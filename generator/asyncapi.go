@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// OutputAsyncAPI is the output mode generating one AsyncAPI document per proto package from streaming methods
+const OutputAsyncAPI = "asyncapi"
+
+type AsyncAPIDocument struct {
+	AsyncAPI   string                      `json:"asyncapi"`
+	Info       *OpenAPIInfo                `json:"info"`
+	Channels   map[string]*AsyncAPIChannel `json:"channels"`
+	Components *AsyncAPIComponents         `json:"components"`
+	// owners maps schema names to the full names of the messages they were generated from
+	owners map[string]string
+}
+
+type AsyncAPIComponents struct {
+	Schemas  map[string]*Schema          `json:"schemas"`
+	Messages map[string]*AsyncAPIMessage `json:"messages"`
+}
+
+type AsyncAPIChannel struct {
+	Description string             `json:"description,omitempty"`
+	Publish     *AsyncAPIOperation `json:"publish,omitempty"`
+	Subscribe   *AsyncAPIOperation `json:"subscribe,omitempty"`
+	Streaming   string             `json:"x-grpc-streaming"`
+}
+
+type AsyncAPIOperation struct {
+	OperationId string          `json:"operationId"`
+	Message     *SchemaProperty `json:"message"`
+}
+
+type AsyncAPIMessage struct {
+	Name        string          `json:"name"`
+	Title       string          `json:"title,omitempty"`
+	ContentType string          `json:"contentType"`
+	Payload     *SchemaProperty `json:"payload"`
+}
+
+// NewAsyncAPIDocument creates a new AsyncAPIDocument struct
+func NewAsyncAPIDocument(title, version string) *AsyncAPIDocument {
+	return &AsyncAPIDocument{
+		AsyncAPI: "2.6.0",
+		Info:     &OpenAPIInfo{Title: title, Version: version},
+		Channels: make(map[string]*AsyncAPIChannel),
+		Components: &AsyncAPIComponents{
+			Schemas:  make(map[string]*Schema),
+			Messages: make(map[string]*AsyncAPIMessage),
+		},
+		owners: make(map[string]string),
+	}
+}
+
+// Json serializes the document into JSON format
+func (d *AsyncAPIDocument) Json() []byte {
+	bytes, err := json.MarshalIndent(d, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes[:]
+}
+
+// asyncAPI checks if schemas are generated as components of AsyncAPI documents
+func (g *JSONSchemaGenerator) asyncAPI() bool {
+	return *g.cfg.Output == OutputAsyncAPI
+}
+
+// buildAsyncAPIDocuments builds one AsyncAPI document per proto package, with a channel per streaming method
+func (g *JSONSchemaGenerator) buildAsyncAPIDocuments() error {
+	documents := make(map[string]*AsyncAPIDocument)
+	packages := []string{}
+	for _, file := range g.plugin.Files {
+		if !file.Generate || g.getFileOptions(file.Desc).GetIgnore() {
+			continue
+		}
+		pkg := string(file.Desc.Package())
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				if streamingKind(method) == StreamingUnary || g.getMethodOptions(method).GetIgnore() {
+					continue
+				}
+				document, ok := documents[pkg]
+				if !ok {
+					title, version := pkg, packageVersion(pkg)
+					if title == "" {
+						title = file.Desc.Path()
+					}
+					if version == "" {
+						version = "0.0.0"
+					}
+					document = NewAsyncAPIDocument(title, version)
+					documents[pkg] = document
+					packages = append(packages, pkg)
+				}
+				if err := g.addChannel(document, service, method); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, pkg := range packages {
+		for name, schema := range documents[pkg].Components.Schemas {
+			if err := g.applyDraftToSchema(schema); err != nil {
+				return fmt.Errorf("invalid extra keywords for schema %s: %w", name, err)
+			}
+		}
+		filename := "asyncapi" + g.fileExtension()
+		if pkg != "" {
+			filename = strings.ReplaceAll(pkg, ".", "/") + "/" + filename
+		}
+		if err := g.writeFile(filename, documents[pkg].Json()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addChannel adds the channel of the streaming method to the document. Requests are published to the service and
+// responses are received by subscribing to it
+func (g *JSONSchemaGenerator) addChannel(document *AsyncAPIDocument, service *protogen.Service, method *protogen.Method) error {
+	description := g.reformatComment(method.Comments.Leading)
+	if g.isDeprecated(method.Desc) {
+		description = g.deprecatedDescription(description)
+	}
+	channel := &AsyncAPIChannel{Description: description, Streaming: streamingKind(method)}
+	operations := []struct {
+		operation **AsyncAPIOperation
+		kind      string
+		message   *protogen.Message
+	}{{&channel.Publish, "publish", method.Input}, {&channel.Subscribe, "subscribe", method.Output}}
+	for _, operation := range operations {
+		message, err := g.addAsyncAPIMessage(document, operation.message)
+		if err != nil {
+			return err
+		}
+		*operation.operation = &AsyncAPIOperation{
+			OperationId: fmt.Sprintf("%s_%s_%s", service.Desc.Name(), method.Desc.Name(), operation.kind),
+			Message:     message,
+		}
+	}
+	document.Channels[fmt.Sprintf("%s/%s", service.Desc.FullName(), method.Desc.Name())] = channel
+	return nil
+}
+
+// addAsyncAPIMessage adds the message and its payload schema to the components of the document, and returns a reference to it
+func (g *JSONSchemaGenerator) addAsyncAPIMessage(document *AsyncAPIDocument, message *protogen.Message) (*SchemaProperty, error) {
+	name := g.definitionName(message)
+	added, err := g.addDefinition(document.Components.Schemas, document.owners, message)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, fmt.Errorf("message %s is used by a streaming method but is ignored", message.Desc.FullName())
+	}
+	document.Components.Messages[name] = &AsyncAPIMessage{
+		Name:        string(message.Desc.FullName()),
+		Title:       string(message.Desc.Name()),
+		ContentType: "application/json",
+		Payload:     &SchemaProperty{Ref: g.definitionsRef(name)},
+	}
+	return &SchemaProperty{Ref: fmt.Sprintf("#/components/messages/%s", name)}, nil
+}
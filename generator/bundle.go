@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Bundle modes supported by the bundle parameter
+const (
+	BundleNone    = ""
+	BundleFile    = "file"
+	BundlePackage = "package"
+)
+
+// checkBundle checks that the configured bundle mode is supported
+func (g *JSONSchemaGenerator) checkBundle() error {
+	switch *g.cfg.Bundle {
+	case BundleNone:
+		return nil
+	case BundleFile, BundlePackage:
+		if g.openAPI() || g.asyncAPI() {
+			return fmt.Errorf("bundle %q can't be combined with output %q, which always bundles by package", *g.cfg.Bundle, *g.cfg.Output)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported bundle %q. Use %q or %q", *g.cfg.Bundle, BundleFile, BundlePackage)
+	}
+}
+
+// bundled checks if messages are generated as definitions of a single document instead of schema files of their own
+func (g *JSONSchemaGenerator) bundled() bool {
+	return g.openAPI() || g.asyncAPI() || *g.cfg.Bundle != BundleNone || g.selfContained
+}
+
+// addDefinition adds the schema of the message and of the messages it references to the shared definitions of a document.
+// It reports whether the message has a schema of its own in the definitions
+func (g *JSONSchemaGenerator) addDefinition(definitions map[string]*Schema, owners map[string]string, message *protogen.Message) (bool, error) {
+	name, fullName := g.definitionName(message), string(message.Desc.FullName())
+	if owner, ok := owners[name]; ok {
+		if owner != fullName {
+			return false, fmt.Errorf("messages %s and %s both map to definition %q. Use the full name definitions naming", owner, fullName, name)
+		}
+		return true, nil
+	}
+	schema, err := g.parseMessage(message, nil)
+	if err != nil || schema == nil {
+		return false, err
+	}
+	// the message is registered before the messages it references so that recursive references end here
+	owners[name] = fullName
+	// references are resolved against the document, so schemas must not change the base URI
+	schema.Id, schema.SchemaRef = "", ""
+	schema.Definitions = nil
+	definitions[name] = schema
+	referenced, err := g.referencedMessages(message)
+	if err != nil {
+		return false, err
+	}
+	for _, reference := range referenced {
+		if _, err := g.addDefinition(definitions, owners, reference); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// referencedMessages returns the messages which the properties generated from the fields of the message reference
+func (g *JSONSchemaGenerator) referencedMessages(message *protogen.Message) ([]*protogen.Message, error) {
+	referenced := []*protogen.Message{}
+	for _, field := range message.Fields {
+		property, err := g.parseField(field)
+		if err != nil {
+			return nil, err
+		}
+		if property != nil && property.IsRef {
+			referenced = append(referenced, field.Message)
+		}
+	}
+	return referenced, nil
+}
+
+// bundleName returns the name of the bundle the file belongs to
+func (g *JSONSchemaGenerator) bundleName(file *protogen.File) string {
+	if *g.cfg.Bundle == BundlePackage && file.Desc.Package() != "" {
+		return string(file.Desc.Package())
+	}
+	return strings.TrimSuffix(path.Base(file.Desc.Path()), ".proto")
+}
+
+// buildBundles builds one schema per proto file or package, with every message in its definitions and the
+// top-level messages as entry points
+func (g *JSONSchemaGenerator) buildBundles() error {
+	bundles := make(map[string]*Schema)
+	owners := make(map[string]map[string]string)
+	// sources maps bundle files to the proto file or package they are built from
+	sources := make(map[string]string)
+	names := []string{}
+	for _, file := range g.plugin.Files {
+		if !g.includedFile(file) {
+			continue
+		}
+		messages := g.includedMessages(file)
+		// imports only get a bundle when they contribute messages
+		if !file.Generate && len(messages) == 0 {
+			continue
+		}
+		fileOpts := g.getFileOptions(file.Desc)
+		bundleName := g.bundleName(file)
+		name := g.outputPath(file.Desc, bundleName)
+		title := file.Desc.Path()
+		if *g.cfg.Bundle == BundlePackage && file.Desc.Package() != "" {
+			title = string(file.Desc.Package())
+		}
+		if source, ok := sources[name]; ok && source != title {
+			return fmt.Errorf("%s and %s are both bundled into %s. Use the paths parameter to separate them", source, title, name)
+		}
+		bundle, ok := bundles[name]
+		if !ok {
+			fullName := bundleName
+			if pkg := string(file.Desc.Package()); pkg != "" && bundleName != pkg {
+				fullName = pkg + "." + bundleName
+			}
+			bundle = NewSchema(g.schemaId(file.Desc, bundleName, fullName, name), fileOpts.GetTitlePrefix()+title, "", "")
+			bundles[name] = bundle
+			sources[name] = title
+			owners[name] = make(map[string]string)
+			names = append(names, name)
+		}
+		for _, message := range messages {
+			added, err := g.addDefinition(bundle.Definitions, owners[name], message)
+			if err != nil {
+				return err
+			}
+			if added {
+				bundle.AnyOf = append(bundle.AnyOf, &SchemaProperty{Ref: g.definitionsRef(g.definitionName(message))})
+			}
+		}
+	}
+	for _, name := range names {
+		if err := g.applyDraft(bundles[name]); err != nil {
+			return fmt.Errorf("invalid extra keywords for bundle %s: %w", name, err)
+		}
+		if err := g.writeFile(name, bundles[name].Json()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// legacyFormats maps the formats of generated schemas to the format vocabulary of OpenAPI 3.0 and Swagger 2.0
+var legacyFormats = map[string]string{
+	"uint32":  "int64",
+	"uint64":  "int64",
+	"float32": "float",
+	"float64": "double",
+}
+
+// addExtra adds the keyword to the extra keywords unless they already set it
+func addExtra(extra map[string]interface{}, keyword string, value interface{}) map[string]interface{} {
+	if extra == nil {
+		extra = make(map[string]interface{})
+	}
+	if _, ok := extra[keyword]; !ok {
+		extra[keyword] = value
+	}
+	return extra
+}
+
+// applyNullable writes the nullability of a property in the way of the configured draft or OpenAPI dialect
+func (g *JSONSchemaGenerator) applyNullable(property *SchemaProperty) {
+	if !property.Nullable {
+		return
+	}
+	property.Nullable = false
+	switch {
+	case g.swagger():
+		property.Extra = addExtra(property.Extra, "x-nullable", true)
+	case g.legacyOpenAPI():
+		property.Extra = addExtra(property.Extra, "nullable", true)
+	case property.Type != nil:
+		property.Type = []interface{}{property.Type, "null"}
+	default:
+		// properties without a type, like references, accept null as an alternative to their validation keywords,
+		// while their annotations and extra keywords stay on the property
+		nonNull := *property
+		nonNull.Title, nonNull.Description, nonNull.Default, nonNull.Examples = "", "", nil, nil
+		nonNull.ReadOnly, nonNull.WriteOnly, nonNull.Deprecated = false, false, false
+		nonNull.Extra, nonNull.IsRequired, nonNull.IsRef = nil, false, false
+		*property = SchemaProperty{
+			Title:       property.Title,
+			Description: property.Description,
+			Default:     property.Default,
+			Examples:    property.Examples,
+			ReadOnly:    property.ReadOnly,
+			WriteOnly:   property.WriteOnly,
+			Deprecated:  property.Deprecated,
+			AnyOf:       []*SchemaProperty{&nonNull, {Type: "null"}},
+			Extra:       property.Extra,
+			IsRequired:  property.IsRequired,
+			IsRef:       property.IsRef,
+		}
+	}
+}
+
+// applyLegacyOpenAPIToProperty rewrites the draft-04 keywords of a property into the OpenAPI 3.0 or Swagger 2.0 dialect.
+// Formats are renamed to their OpenAPI names, and the keywords neither dialect supports, like "propertyNames", become
+// extensions. Swagger 2.0 also lacks "anyOf", "not", "writeOnly" and "deprecated", which become extensions too
+func (g *JSONSchemaGenerator) applyLegacyOpenAPIToProperty(property *SchemaProperty) {
+	if format, ok := legacyFormats[property.Format]; ok {
+		property.Format = format
+	}
+	// neither dialect has the null type, so google.protobuf.NullValue is written as nullable
+	if property.Type == "null" {
+		property.Type, property.Nullable = nil, true
+		g.applyNullable(property)
+	}
+	if len(property.Examples) > 0 {
+		property.Extra = addExtra(property.Extra, "example", property.Examples[0])
+		property.Examples = nil
+	}
+	if property.PropertyNames != nil {
+		property.Extra = addExtra(property.Extra, "x-propertyNames", property.PropertyNames)
+		property.PropertyNames = nil
+		g.unvalidated["propertyNames"] = true
+	}
+	// enum values annotated one by one collapse back into the enum keyword
+	if values, ok := g.enumValues(property.AnyOf); ok {
+		property.Enum = values
+		property.AnyOf = nil
+	}
+	if !g.swagger() {
+		return
+	}
+	if len(property.AnyOf) > 0 {
+		property.Extra = addExtra(property.Extra, "x-anyOf", property.AnyOf)
+		property.AnyOf = nil
+		g.unvalidated["anyOf"] = true
+	}
+	if property.Not != nil {
+		property.Extra = addExtra(property.Extra, "x-not", property.Not)
+		property.Not = nil
+		g.unvalidated["not"] = true
+	}
+	if property.WriteOnly {
+		property.Extra = addExtra(property.Extra, "x-writeOnly", true)
+		property.WriteOnly = false
+	}
+	if property.Deprecated {
+		property.Extra = addExtra(property.Extra, "x-deprecated", true)
+		property.Deprecated = false
+	}
+}
+
+// applyLegacyOpenAPIToSchema rewrites the draft-04 keywords of a message schema into the OpenAPI 3.0 or Swagger 2.0
+// dialect. The keywords describing the properties as a whole, like "dependencies" and "patternProperties", become
+// extensions, and "deprecated" does too in Swagger 2.0
+func (g *JSONSchemaGenerator) applyLegacyOpenAPIToSchema(schema *Schema) {
+	if len(schema.Examples) > 0 {
+		schema.Extra = addExtra(schema.Extra, "example", schema.Examples[0])
+		schema.Examples = nil
+	}
+	if len(schema.Dependencies) > 0 {
+		schema.Extra = addExtra(schema.Extra, "x-dependencies", schema.Dependencies)
+		schema.Dependencies = nil
+		g.unvalidated["dependencies"] = true
+	}
+	if len(schema.PatternProperties) > 0 {
+		schema.Extra = addExtra(schema.Extra, "x-patternProperties", schema.PatternProperties)
+		schema.PatternProperties = nil
+		// the properties matching the dropped patterns, like protojson extension fields, must stay valid
+		schema.AdditionalProperties = nil
+	}
+	if g.swagger() && schema.Deprecated {
+		schema.Extra = addExtra(schema.Extra, "x-deprecated", true)
+		schema.Deprecated = false
+	}
+}
+
+// warnUnvalidated warns about the validation keywords which were kept as extensions while rewriting the described schema.
+// Validators ignore extensions, so the schema accepts values which the keywords would reject
+func (g *JSONSchemaGenerator) warnUnvalidated(description string) {
+	if len(g.unvalidated) == 0 {
+		return
+	}
+	keywords := make([]string, 0, len(g.unvalidated))
+	for keyword := range g.unvalidated {
+		keywords = append(keywords, fmt.Sprintf("%q", keyword))
+	}
+	sort.Strings(keywords)
+	fmt.Fprintf(g.warnings, "warning: %s can't express the %s keywords of %s, which are kept as extensions and not validated\n", *g.cfg.Output, strings.Join(keywords, ", "), description)
+	g.unvalidated = make(map[string]bool)
+}
+
+// enumValues returns the values of subschemas that each only allow a single, possibly deprecated, value
+func (g *JSONSchemaGenerator) enumValues(subschemas []*SchemaProperty) ([]interface{}, bool) {
+	if len(subschemas) == 0 {
+		return nil, false
+	}
+	values := []interface{}{}
+	for _, subschema := range subschemas {
+		rest := *subschema
+		rest.Enum, rest.Deprecated = nil, false
+		if len(rest.Extra) == 1 && rest.Extra["x-deprecated"] == true {
+			rest.Extra = nil
+		}
+		if len(subschema.Enum) != 1 || !g.isEmpty(&rest) {
+			return nil, false
+		}
+		values = append(values, subschema.Enum[0])
+	}
+	return values, true
+}
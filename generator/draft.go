@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON schema drafts supported by the draft parameter
+const (
+	Draft04     = "04"
+	Draft07     = "07"
+	Draft201909 = "2019-09"
+	Draft202012 = "2020-12"
+)
+
+var draftURIs = map[string]string{
+	Draft04:     "http://json-schema.org/draft-04/schema#",
+	Draft07:     "http://json-schema.org/draft-07/schema#",
+	Draft201909: "https://json-schema.org/draft/2019-09/schema",
+	Draft202012: "https://json-schema.org/draft/2020-12/schema",
+}
+
+// checkDraft checks that the configured draft is supported
+func (g *JSONSchemaGenerator) checkDraft() error {
+	if _, ok := draftURIs[g.draft()]; !ok {
+		return fmt.Errorf("unsupported draft %q. Use one of %q, %q, %q or %q", *g.cfg.Draft, Draft04, Draft07, Draft201909, Draft202012)
+	}
+	return nil
+}
+
+// draft returns the JSON schema draft of generated schemas. OpenAPI 3.1 documents always use draft 2020-12,
+// while the schemas of OpenAPI 3.0 and Swagger 2.0 are subsets of draft-04
+func (g *JSONSchemaGenerator) draft() string {
+	if g.legacyOpenAPI() {
+		return Draft04
+	}
+	// the default schema format of AsyncAPI 2 is a superset of draft-07
+	if g.asyncAPI() {
+		return Draft07
+	}
+	if g.openAPI() {
+		return Draft202012
+	}
+	return *g.cfg.Draft
+}
+
+// usesDefs checks if the configured draft stores definitions under "$defs" instead of "definitions"
+func (g *JSONSchemaGenerator) usesDefs() bool {
+	draft := g.draft()
+	return draft == Draft201909 || draft == Draft202012
+}
+
+// definitionsRef returns the reference to the definition with the given name
+func (g *JSONSchemaGenerator) definitionsRef(name string) string {
+	if g.swagger() {
+		return fmt.Sprintf("#/definitions/%v", name)
+	}
+	if g.openAPI() || g.asyncAPI() {
+		return fmt.Sprintf("#/components/schemas/%v", name)
+	}
+	if g.usesDefs() {
+		return fmt.Sprintf("#/$defs/%v", name)
+	}
+	return fmt.Sprintf("#/definitions/%v", name)
+}
+
+// applyDraft rewrites the keywords of a schema, which are generated for draft-07, into the configured draft
+func (g *JSONSchemaGenerator) applyDraft(schema *Schema) error {
+	schema.SchemaRef = draftURIs[g.draft()]
+	return g.applyDraftToSchema(schema)
+}
+
+// applyDraftToSchema rewrites the keywords of a schema and its definitions into the configured draft
+func (g *JSONSchemaGenerator) applyDraftToSchema(schema *Schema) error {
+	switch g.draft() {
+	case Draft04:
+		// draft-04 predates "$id"
+		schema.LegacyId, schema.Id = schema.Id, ""
+		schema.AllOf = append(schema.AllOf, g.conditionalToAllOf(schema.If, schema.Then, schema.Else)...)
+		schema.If, schema.Then, schema.Else = nil, nil, nil
+	case Draft201909, Draft202012:
+		schema.Defs, schema.Definitions = schema.Definitions, nil
+		schema.DependentRequired, schema.Dependencies = schema.Dependencies, nil
+	}
+	for _, property := range schema.Properties {
+		if err := g.applyDraftToProperty(property.Property); err != nil {
+			return err
+		}
+	}
+	for _, property := range schema.PatternProperties {
+		if err := g.applyDraftToProperty(property); err != nil {
+			return err
+		}
+	}
+	if property, ok := schema.AdditionalProperties.(*SchemaProperty); ok {
+		if err := g.applyDraftToProperty(property); err != nil {
+			return err
+		}
+	}
+	subschemas := append([]*SchemaProperty{schema.If, schema.Then, schema.Else}, schema.AllOf...)
+	for _, property := range append(subschemas, schema.AnyOf...) {
+		if err := g.applyDraftToProperty(property); err != nil {
+			return err
+		}
+	}
+	for _, definition := range schema.Definitions {
+		if err := g.applyDraftToSchema(definition); err != nil {
+			return err
+		}
+	}
+	for _, definition := range schema.Defs {
+		if err := g.applyDraftToSchema(definition); err != nil {
+			return err
+		}
+	}
+	if g.legacyOpenAPI() {
+		g.applyLegacyOpenAPIToSchema(schema)
+	}
+	// extra keywords were checked against the draft-07 keywords, so they are checked again once those are rewritten
+	generated := *schema
+	generated.Extra = nil
+	return g.checkExtra(&generated, schema.Extra)
+}
+
+// applyDraftToProperty rewrites the keywords of a property and its subschemas into the configured draft
+func (g *JSONSchemaGenerator) applyDraftToProperty(property *SchemaProperty) error {
+	if property == nil {
+		return nil
+	}
+	g.applyNullable(property)
+	if g.draft() == Draft04 {
+		// draft-04 expresses exclusive bounds as booleans next to the bounds
+		if property.ExclusiveMinimum != nil {
+			if property.Minimum != nil {
+				property.AllOf = append(property.AllOf, &SchemaProperty{Minimum: property.Minimum})
+			}
+			property.Minimum, property.ExclusiveMinimum = property.ExclusiveMinimum, true
+		}
+		if property.ExclusiveMaximum != nil {
+			if property.Maximum != nil {
+				property.AllOf = append(property.AllOf, &SchemaProperty{Maximum: property.Maximum})
+			}
+			property.Maximum, property.ExclusiveMaximum = property.ExclusiveMaximum, true
+		}
+		// draft-04 predates "const"
+		if property.Const != nil {
+			if property.Enum == nil {
+				property.Enum = []interface{}{property.Const}
+			} else {
+				property.AllOf = append(property.AllOf, &SchemaProperty{Enum: []interface{}{property.Const}})
+			}
+			property.Const = nil
+		}
+		// draft-04 predates "if", "then" and "else"
+		property.AllOf = append(property.AllOf, g.conditionalToAllOf(property.If, property.Then, property.Else)...)
+		property.If, property.Then, property.Else = nil, nil, nil
+	}
+	// draft-04, OpenAPI 3.0 and Swagger 2.0 replace the whole schema with "$ref", so the reference is moved into "allOf".
+	// Draft-07 keeps the siblings as before, since it only ignores them and they are mostly annotations like the description
+	if g.draft() == Draft04 && property.Ref != "" && g.hasSiblings(property) {
+		property.AllOf = append([]*SchemaProperty{{Ref: property.Ref}}, property.AllOf...)
+		property.Ref = ""
+	}
+	for _, subschema := range property.Properties {
+		if err := g.applyDraftToProperty(subschema.Property); err != nil {
+			return err
+		}
+	}
+	subschemas := []*SchemaProperty{property.Items, property.PropertyNames, property.Not, property.If, property.Then, property.Else}
+	if additionalProperties, ok := property.AdditionalProperties.(*SchemaProperty); ok {
+		subschemas = append(subschemas, additionalProperties)
+	}
+	subschemas = append(subschemas, property.AllOf...)
+	subschemas = append(subschemas, property.AnyOf...)
+	for _, subschema := range subschemas {
+		if err := g.applyDraftToProperty(subschema); err != nil {
+			return err
+		}
+	}
+	g.applyDraftToTupleItems(property.Extra)
+	if g.legacyOpenAPI() {
+		g.applyLegacyOpenAPIToProperty(property)
+	}
+	generated := *property
+	generated.Extra = nil
+	return g.checkExtra(&generated, property.Extra)
+}
+
+// applyDraftToTupleItems rewrites tuple arrays of extra keywords between the "items" array of drafts before 2020-12
+// and "prefixItems". Generated arrays need no rewriting, as repeated fields validate every item with the same schema
+// under "items" in every draft
+func (g *JSONSchemaGenerator) applyDraftToTupleItems(extra map[string]interface{}) {
+	if g.draft() == Draft202012 {
+		if items, ok := extra["items"].([]interface{}); ok {
+			delete(extra, "items")
+			// the items after the tuple are validated by "items" instead of "additionalItems"
+			if additionalItems, ok := extra["additionalItems"]; ok {
+				delete(extra, "additionalItems")
+				extra["items"] = additionalItems
+			}
+			extra["prefixItems"] = items
+		}
+		return
+	}
+	if prefixItems, ok := extra["prefixItems"].([]interface{}); ok {
+		delete(extra, "prefixItems")
+		if items, ok := extra["items"]; ok {
+			extra["additionalItems"] = items
+		}
+		extra["items"] = prefixItems
+	}
+}
+
+// conditionalToAllOf expresses "if", "then" and "else" with boolean logic: (not if or then) and (if or else)
+func (g *JSONSchemaGenerator) conditionalToAllOf(ifSchema, thenSchema, elseSchema *SchemaProperty) []*SchemaProperty {
+	if ifSchema == nil {
+		return nil
+	}
+	allOf := []*SchemaProperty{}
+	if thenSchema != nil {
+		allOf = append(allOf, &SchemaProperty{AnyOf: []*SchemaProperty{{Not: ifSchema}, thenSchema}})
+	}
+	if elseSchema != nil {
+		allOf = append(allOf, &SchemaProperty{AnyOf: []*SchemaProperty{ifSchema, elseSchema}})
+	}
+	return allOf
+}
+
+// hasSiblings checks if the property has keywords other than "$ref"
+func (g *JSONSchemaGenerator) hasSiblings(property *SchemaProperty) bool {
+	siblings := *property
+	siblings.Ref = ""
+	return !g.isEmpty(&siblings)
+}
+
+// isEmpty checks if the property has no keywords
+func (g *JSONSchemaGenerator) isEmpty(property *SchemaProperty) bool {
+	bytes, err := json.Marshal(property)
+	return err == nil && string(bytes) == "{}"
+}
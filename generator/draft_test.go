@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyDraftToTupleItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   string
+		extra    string
+		expected string
+	}{
+		{name: "items array to prefixItems", params: "draft=2020-12", extra: `{"items":[{"type":"string"}]}`, expected: `{"prefixItems":[{"type":"string"}]}`},
+		{name: "additionalItems to items", params: "draft=2020-12", extra: `{"items":[{"type":"string"}],"additionalItems":false}`, expected: `{"items":false,"prefixItems":[{"type":"string"}]}`},
+		{name: "items schema kept", params: "draft=2020-12", extra: `{"items":{"type":"string"}}`, expected: `{"items":{"type":"string"}}`},
+		{name: "prefixItems to items array", params: "draft=07", extra: `{"prefixItems":[{"type":"string"}]}`, expected: `{"items":[{"type":"string"}]}`},
+		{name: "items to additionalItems", params: "draft=2019-09", extra: `{"prefixItems":[{"type":"string"}],"items":false}`, expected: `{"additionalItems":false,"items":[{"type":"string"}]}`},
+		{name: "items array kept", params: "draft=04", extra: `{"items":[{"type":"string"}]}`, expected: `{"items":[{"type":"string"}]}`},
+		{name: "no tuple", params: "draft=2020-12", extra: `{"contains":{"type":"string"}}`, expected: `{"contains":{"type":"string"}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			extra := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(test.extra), &extra); err != nil {
+				t.Fatal(err)
+			}
+			newTestGenerator(t, test.params).applyDraftToTupleItems(extra)
+			bytes, err := json.Marshal(extra)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(bytes) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, bytes)
+			}
+		})
+	}
+}
+
+func TestConditionalToAllOf(t *testing.T) {
+	ifSchema := &SchemaProperty{Required: []string{"a"}}
+	thenSchema := &SchemaProperty{Required: []string{"b"}}
+	elseSchema := &SchemaProperty{Required: []string{"c"}}
+	tests := []struct {
+		name                             string
+		ifSchema, thenSchema, elseSchema *SchemaProperty
+		expected                         string
+	}{
+		{name: "no if", thenSchema: thenSchema, elseSchema: elseSchema, expected: `null`},
+		{name: "if only", ifSchema: ifSchema, expected: `[]`},
+		{name: "if then", ifSchema: ifSchema, thenSchema: thenSchema, expected: `[{"anyOf":[{"not":{"required":["a"]}},{"required":["b"]}]}]`},
+		{name: "if else", ifSchema: ifSchema, elseSchema: elseSchema, expected: `[{"anyOf":[{"required":["a"]},{"required":["c"]}]}]`},
+		{name: "if then else", ifSchema: ifSchema, thenSchema: thenSchema, elseSchema: elseSchema, expected: `[{"anyOf":[{"not":{"required":["a"]}},{"required":["b"]}]},{"anyOf":[{"required":["a"]},{"required":["c"]}]}]`},
+	}
+	g := newTestGenerator(t, "draft=04")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytes, err := json.Marshal(g.conditionalToAllOf(test.ifSchema, test.thenSchema, test.elseSchema))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(bytes) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, bytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+var resourceVariablePattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// collectResourcePatterns maps the type of every google.api.resource declared in the plugin files to its name patterns
+func collectResourcePatterns(plugin *protogen.Plugin) map[string][]string {
+	resourcePatterns := make(map[string][]string)
+	var collect func(messages []*protogen.Message)
+	collect = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			if resource, ok := proto.GetExtension(message.Desc.Options(), annotations.E_Resource).(*annotations.ResourceDescriptor); ok && resource != nil {
+				resourcePatterns[resource.GetType()] = append(resourcePatterns[resource.GetType()], resource.GetPattern()...)
+			}
+			collect(message.Messages)
+		}
+	}
+	for _, file := range plugin.Files {
+		if resources, ok := proto.GetExtension(file.Desc.Options(), annotations.E_ResourceDefinition).([]*annotations.ResourceDescriptor); ok {
+			for _, resource := range resources {
+				resourcePatterns[resource.GetType()] = append(resourcePatterns[resource.GetType()], resource.GetPattern()...)
+			}
+		}
+		collect(file.Messages)
+	}
+	return resourcePatterns
+}
+
+// resourceNameRegex compiles a resource name pattern such as "projects/{project}/books/{book}" into an anchored regular expression
+func resourceNameRegex(pattern string) string {
+	literals := resourceVariablePattern.Split(pattern, -1)
+	for i, literal := range literals {
+		literals[i] = regexp.QuoteMeta(literal)
+	}
+	return "^" + strings.Join(literals, "[^/]+") + "$"
+}
+
+// parentPattern returns the pattern of the parent of a resource, e.g. "projects/{project}" for "projects/{project}/books/{book}"
+func parentPattern(pattern string) (string, bool) {
+	segments := strings.Split(pattern, "/")
+	if len(segments) < 4 {
+		return "", false
+	}
+	return strings.Join(segments[:len(segments)-2], "/"), true
+}
+
+// applyFieldBehavior translates the google.api.field_behavior and google.api.field_info annotations of the field into keywords of the SchemaProperty
+func (g *JSONSchemaGenerator) applyFieldBehavior(field *protogen.Field, propertySchema *SchemaProperty) {
+	if behaviors, ok := proto.GetExtension(field.Desc.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior); ok {
+		for _, behavior := range behaviors {
+			switch behavior {
+			case annotations.FieldBehavior_REQUIRED:
+				propertySchema.IsRequired = true
+			case annotations.FieldBehavior_OUTPUT_ONLY:
+				propertySchema.ReadOnly = true
+			case annotations.FieldBehavior_INPUT_ONLY:
+				propertySchema.WriteOnly = true
+			case annotations.FieldBehavior_IMMUTABLE:
+				if propertySchema.Extra == nil {
+					propertySchema.Extra = make(map[string]interface{})
+				}
+				propertySchema.Extra["x-immutable"] = true
+			}
+		}
+	}
+	// formats apply to the items of repeated fields
+	target := propertySchema
+	if propertySchema.Items != nil {
+		target = propertySchema.Items
+	}
+	if fieldInfo, ok := proto.GetExtension(field.Desc.Options(), annotations.E_FieldInfo).(*annotations.FieldInfo); ok {
+		switch fieldInfo.GetFormat() {
+		case annotations.FieldInfo_UUID4:
+			g.addFormat(target, "uuid")
+		case annotations.FieldInfo_IPV4:
+			g.addFormat(target, "ipv4")
+		case annotations.FieldInfo_IPV6:
+			g.addFormat(target, "ipv6")
+		case annotations.FieldInfo_IPV4_OR_IPV6:
+			g.addAnyOf(target, &SchemaProperty{Format: "ipv4"}, &SchemaProperty{Format: "ipv6"})
+		}
+	}
+}
+
+// applyResourceReference constrains the string fields annotated with google.api.resource_reference to the name patterns of the referenced resource
+func (g *JSONSchemaGenerator) applyResourceReference(field *protogen.Field, propertySchema *SchemaProperty) {
+	reference, ok := proto.GetExtension(field.Desc.Options(), annotations.E_ResourceReference).(*annotations.ResourceReference)
+	if !ok || reference == nil {
+		return
+	}
+	patterns := []string{}
+	if resourceType := reference.GetType(); resourceType != "" {
+		patterns = append(patterns, g.resourcePatterns[resourceType]...)
+	} else if childType := reference.GetChildType(); childType != "" {
+		for _, childPattern := range g.resourcePatterns[childType] {
+			if pattern, ok := parentPattern(childPattern); ok {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+	if len(patterns) == 0 {
+		return
+	}
+	// patterns apply to the items of repeated fields
+	target := propertySchema
+	if propertySchema.Items != nil {
+		target = propertySchema.Items
+	}
+	if len(patterns) == 1 {
+		g.addPattern(target, resourceNameRegex(patterns[0]))
+		return
+	}
+	alternatives := []*SchemaProperty{}
+	for _, pattern := range patterns {
+		alternatives = append(alternatives, &SchemaProperty{Pattern: resourceNameRegex(pattern)})
+	}
+	g.addAnyOf(target, alternatives...)
+}
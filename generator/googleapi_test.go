@@ -0,0 +1,43 @@
+package generator
+
+import "testing"
+
+func TestResourceNameRegex(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "shelves/{shelf}", expected: `^shelves/[^/]+$`},
+		{pattern: "projects/{project}/books/{book}", expected: `^projects/[^/]+/books/[^/]+$`},
+		{pattern: "projects/{project}/locations/{location}.json", expected: `^projects/[^/]+/locations/[^/]+\.json$`},
+		{pattern: "config", expected: `^config$`},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			if regex := resourceNameRegex(test.pattern); regex != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, regex)
+			}
+		})
+	}
+}
+
+func TestParentPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+		ok       bool
+	}{
+		{pattern: "projects/{project}/books/{book}", expected: "projects/{project}", ok: true},
+		{pattern: "projects/{project}/shelves/{shelf}/books/{book}", expected: "projects/{project}/shelves/{shelf}", ok: true},
+		{pattern: "shelves/{shelf}"},
+		{pattern: "config"},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			pattern, ok := parentPattern(test.pattern)
+			if pattern != test.expected || ok != test.ok {
+				t.Errorf("expected %q, %v, got %q, %v", test.expected, test.ok, pattern, ok)
+			}
+		})
+	}
+}
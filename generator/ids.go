@@ -0,0 +1,254 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// idVariablePattern matches the placeholders of the id template
+	idVariablePattern = regexp.MustCompile(`\{[^{}]*\}`)
+	// duplicateSlashPattern matches the slashes left over by empty placeholders, except those following a URI scheme
+	duplicateSlashPattern = regexp.MustCompile(`([^:/])//+`)
+)
+
+// idVariables are the placeholders supported by the id template
+var idVariables = map[string]bool{
+	"{package}":   true,
+	"{name}":      true,
+	"{full_name}": true,
+	"{file}":      true,
+	"{version}":   true,
+}
+
+// checkIdTemplate checks that the configured id template only uses supported placeholders
+func (g *JSONSchemaGenerator) checkIdTemplate() error {
+	for _, variable := range idVariablePattern.FindAllString(*g.cfg.IdTemplate, -1) {
+		if !idVariables[variable] {
+			return fmt.Errorf("unsupported placeholder %s in id template %q. Use {package}, {name}, {full_name}, {file} or {version}", variable, *g.cfg.IdTemplate)
+		}
+	}
+	return nil
+}
+
+// checkIdBase checks that the id_base parameter and the id_base annotations of the generated files end with a slash,
+// as the ids are appended to them
+func (g *JSONSchemaGenerator) checkIdBase() error {
+	if idBase := *g.cfg.IdBase; idBase != "" && !strings.HasSuffix(idBase, "/") {
+		return fmt.Errorf("id base %q must end with a slash", idBase)
+	}
+	for _, file := range g.plugin.Files {
+		if !g.includedFile(file) {
+			continue
+		}
+		if idBase := g.getFileOptions(file.Desc).GetIdBase(); idBase != "" && !strings.HasSuffix(idBase, "/") {
+			return fmt.Errorf("id base %q of file %s must end with a slash", idBase, file.Desc.Path())
+		}
+	}
+	return nil
+}
+
+// idBase returns the base URI of the ids of the schemas generated from the file. The file annotation overrides the id_base parameter
+func (g *JSONSchemaGenerator) idBase(file protoreflect.FileDescriptor) string {
+	if idBase := g.getFileOptions(file).GetIdBase(); idBase != "" {
+		return idBase
+	}
+	return *g.cfg.IdBase
+}
+
+// hostedIds checks if the ids of the schemas generated from the file are derived from a base URI or a template,
+// rather than from the file name
+func (g *JSONSchemaGenerator) hostedIds(file protoreflect.FileDescriptor) bool {
+	return g.idBase(file) != "" || *g.cfg.IdTemplate != ""
+}
+
+// schemaId returns the id of the schema with the given name, generated from the file at the given output path.
+// Without an id base or template, the id is the file name so that it resolves to the schema itself wherever the
+// layout puts it. With a base only, the id mirrors the layout below the base
+func (g *JSONSchemaGenerator) schemaId(file protoreflect.FileDescriptor, name, fullName, outputPath string) string {
+	idBase := g.idBase(file)
+	template := *g.cfg.IdTemplate
+	if template == "" {
+		if idBase == "" {
+			return path.Base(outputPath)
+		}
+		return idBase + outputPath
+	}
+	id := strings.NewReplacer(
+		"{package}", string(file.Package()),
+		"{name}", name,
+		"{full_name}", fullName,
+		"{file}", strings.TrimSuffix(file.Path(), ".proto"),
+		"{version}", packageVersion(string(file.Package())),
+	).Replace(template)
+	return duplicateSlashPattern.ReplaceAllString(idBase+id, "$1/")
+}
+
+// messageId returns the id of the schema generated from the message, which the id annotation of the message overrides
+func (g *JSONSchemaGenerator) messageId(desc protoreflect.MessageDescriptor) string {
+	if id := g.getMessageOptions(desc).GetId(); id != "" {
+		return id
+	}
+	file, name := desc.ParentFile(), string(desc.Name())
+	return g.schemaId(file, name, string(desc.FullName()), g.outputPath(file, name))
+}
+
+// topLevelMessage returns the top-level message in which the message is nested, or the message itself
+func topLevelMessage(desc protoreflect.MessageDescriptor) protoreflect.MessageDescriptor {
+	for {
+		parent, ok := desc.Parent().(protoreflect.MessageDescriptor)
+		if !ok {
+			return desc
+		}
+		desc = parent
+	}
+}
+
+// collectDocuments records the top-level messages which get a schema of their own, and fails if two messages,
+// service indexes or method schemas would be written to the same file
+func (g *JSONSchemaGenerator) collectDocuments() error {
+	g.documents = make(map[protoreflect.FullName]bool)
+	paths := make(map[string]protoreflect.FullName)
+	register := func(outputPath string, fullName protoreflect.FullName) error {
+		if other, ok := paths[outputPath]; ok {
+			return fmt.Errorf("%s and %s are both written to %s. Use the paths parameter to separate them", other, fullName, outputPath)
+		}
+		paths[outputPath] = fullName
+		return nil
+	}
+	for _, file := range g.plugin.Files {
+		if !g.includedFile(file) {
+			continue
+		}
+		if !g.bundled() {
+			for _, message := range g.includedMessages(file) {
+				if g.getMessageOptions(message.Desc).GetIgnore() {
+					continue
+				}
+				if err := register(g.outputPath(file.Desc, string(message.Desc.Name())), message.Desc.FullName()); err != nil {
+					return err
+				}
+				g.documents[message.Desc.FullName()] = true
+			}
+		}
+		if !file.Generate || !*g.cfg.Services {
+			continue
+		}
+		for _, service := range file.Services {
+			if err := register(g.indexPath(file, service), service.Desc.FullName()); err != nil {
+				return err
+			}
+			for _, method := range service.Methods {
+				if g.getMethodOptions(method).GetIgnore() {
+					continue
+				}
+				if err := register(g.outputPath(file.Desc, methodName(service, method)), method.Desc.FullName()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addNestedDefinitions adds the schemas of the messages nested in the message to its definitions, which references
+// to nested messages point to when definitions aren't repeated
+func (g *JSONSchemaGenerator) addNestedDefinitions(message *protogen.Message, schema *Schema) error {
+	for _, nested := range message.Messages {
+		if nested.Desc.IsMapEntry() {
+			continue
+		}
+		definition, err := g.parseMessage(
+			nested,
+			&Schema{
+				Type:        "object",
+				Description: g.reformatComment(nested.Comments.Leading),
+				Definitions: make(map[string]*Schema),
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if definition == nil {
+			continue
+		}
+		name := g.definitionName(nested)
+		if _, ok := schema.Definitions[name]; ok {
+			return fmt.Errorf("message %s has several nested messages named %q. Use the full name definitions naming", message.Desc.FullName(), name)
+		}
+		schema.Definitions[name] = definition
+		if err := g.addNestedDefinitions(nested, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// messageRef returns the reference from the schema of a message to the schema of another message when definitions
+// aren't repeated. Top-level messages are referenced by the document generated for them, nested messages by their
+// definition in the document of their top-level message
+func (g *JSONSchemaGenerator) messageRef(from, target *protogen.Message) (string, error) {
+	fromDocument, targetDocument := topLevelMessage(from.Desc), topLevelMessage(target.Desc)
+	if !g.documents[targetDocument.FullName()] {
+		// the file is generated, but the message is ignored or left out of an opt-in file
+		if file := g.plugin.FilesByPath[targetDocument.ParentFile().Path()]; file != nil && g.includedFile(file) {
+			return "", fmt.Errorf("message %s references %s, which is ignored or not annotated in an opt-in file. Generate %s or set repeated_defs=true", from.Desc.FullName(), target.Desc.FullName(), targetDocument.FullName())
+		}
+		// include_imports never generates the google/protobuf files
+		if wellKnownFile(targetDocument.ParentFile()) {
+			return "", fmt.Errorf("message %s references %s, which has no generated schema. Set repeated_defs=true", from.Desc.FullName(), target.Desc.FullName())
+		}
+		return "", fmt.Errorf("message %s references %s, which has no generated schema. Generate the file of %s, set include_imports or set repeated_defs=true", from.Desc.FullName(), target.Desc.FullName(), targetDocument.FullName())
+	}
+	fragment := ""
+	if target.Desc.FullName() != targetDocument.FullName() {
+		for desc := target.Desc; desc.FullName() != targetDocument.FullName(); desc = desc.Parent().(protoreflect.MessageDescriptor) {
+			if g.getMessageOptions(desc).GetIgnore() {
+				return "", fmt.Errorf("message %s references %s, which is ignored", from.Desc.FullName(), target.Desc.FullName())
+			}
+		}
+		fragment = g.definitionsRef(g.definitionName(target))
+	}
+	if fromDocument.FullName() == targetDocument.FullName() {
+		if fragment == "" {
+			return "#", nil
+		}
+		return fragment, nil
+	}
+	fromFile, targetFile := fromDocument.ParentFile(), targetDocument.ParentFile()
+	if g.hostedIds(fromFile) || g.hostedIds(targetFile) || g.getMessageOptions(fromDocument).GetId() != "" || g.getMessageOptions(targetDocument).GetId() != "" {
+		return relativeURI(g.messageId(fromDocument), g.messageId(targetDocument)) + fragment, nil
+	}
+	return relativeRef(
+		g.outputPath(fromFile, string(fromDocument.Name())),
+		g.outputPath(targetFile, string(targetDocument.Name())),
+	) + fragment, nil
+}
+
+// relativeURI returns the reference from the document with the given id to the document with the target id,
+// relative when both are hosted on the same origin
+func relativeURI(from, target string) string {
+	fromURI, err := url.Parse(from)
+	if err != nil {
+		return target
+	}
+	targetURI, err := url.Parse(target)
+	if err != nil || fromURI.Scheme != targetURI.Scheme || fromURI.Host != targetURI.Host {
+		return target
+	}
+	// opaque URIs, like URNs, have no path to be relative to
+	if fromURI.Opaque != "" || targetURI.Opaque != "" {
+		return target
+	}
+	// an absolute path can't be made relative to a relative one
+	if strings.HasPrefix(fromURI.Path, "/") != strings.HasPrefix(targetURI.Path, "/") {
+		return target
+	}
+	return relativeRef(strings.TrimPrefix(fromURI.Path, "/"), strings.TrimPrefix(targetURI.Path, "/"))
+}
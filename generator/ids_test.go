@@ -0,0 +1,28 @@
+package generator
+
+import "testing"
+
+func TestRelativeURI(t *testing.T) {
+	tests := []struct {
+		from     string
+		target   string
+		expected string
+	}{
+		{from: "https://example.com/shop/a.json", target: "https://example.com/shop/b.json", expected: "b.json"},
+		{from: "https://example.com/shop/a.json", target: "https://example.com/warehouse/b.json", expected: "../warehouse/b.json"},
+		{from: "https://example.com/a.json", target: "https://example.com/shop/v1/b.json", expected: "shop/v1/b.json"},
+		{from: "https://example.com/shop/a.json", target: "https://schemas.example.com/shop/b.json", expected: "https://schemas.example.com/shop/b.json"},
+		{from: "http://example.com/shop/a.json", target: "https://example.com/shop/b.json", expected: "https://example.com/shop/b.json"},
+		{from: "a.json", target: "shop/b.json", expected: "shop/b.json"},
+		{from: "shop/a.json", target: "https://example.com/shop/b.json", expected: "https://example.com/shop/b.json"},
+		{from: "https://example.com/shop/a.json", target: "b.json", expected: "b.json"},
+		{from: "urn:shop:a", target: "urn:shop:b", expected: "urn:shop:b"},
+	}
+	for _, test := range tests {
+		t.Run(test.from+" to "+test.target, func(t *testing.T) {
+			if uri := relativeURI(test.from, test.target); uri != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, uri)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	protoc_gen_jsonschema "github.com/TheRebelOfBabylon/protoc-gen-jsonschema"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Modes supported by the include_imports parameter
+const (
+	IncludeImportsNone       = ""
+	IncludeImportsReferenced = "referenced"
+	IncludeImportsAll        = "all"
+)
+
+// checkIncludeImports checks that the configured include_imports mode is supported
+func (g *JSONSchemaGenerator) checkIncludeImports() error {
+	switch *g.cfg.IncludeImports {
+	case IncludeImportsNone:
+		return nil
+	case IncludeImportsReferenced, IncludeImportsAll:
+		if g.openAPI() || g.asyncAPI() {
+			return fmt.Errorf("include_imports %q can't be combined with output %q, which always includes referenced messages as components", *g.cfg.IncludeImports, *g.cfg.Output)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported include_imports %q. Use %q or %q", *g.cfg.IncludeImports, IncludeImportsReferenced, IncludeImportsAll)
+	}
+}
+
+// wellKnownFile checks if the file declares the well-known types, which are mapped to JSON types instead of schemas
+func wellKnownFile(file protoreflect.FileDescriptor) bool {
+	return strings.HasPrefix(file.Path(), "google/protobuf/")
+}
+
+// optionsFile checks if the file declares custom options, like the annotations this generator reads. Its messages
+// describe options instead of data, so include_imports=all doesn't generate them unless they are referenced
+func optionsFile(file protoreflect.FileDescriptor) bool {
+	extensions := file.Extensions()
+	for i := 0; i < extensions.Len(); i++ {
+		if extensions.Get(i).ContainingMessage().ParentFile().Path() == "google/protobuf/descriptor.proto" {
+			return true
+		}
+	}
+	return false
+}
+
+// includedFile checks if schemas are generated for messages of the file, either because it was requested or
+// because it is an import included by the include_imports parameter
+func (g *JSONSchemaGenerator) includedFile(file *protogen.File) bool {
+	if g.getFileOptions(file.Desc).GetIgnore() {
+		return false
+	}
+	return file.Generate || (*g.cfg.IncludeImports != IncludeImportsNone && !wellKnownFile(file.Desc))
+}
+
+// includedMessages returns the top-level messages of the file which get a schema
+func (g *JSONSchemaGenerator) includedMessages(file *protogen.File) []*protogen.Message {
+	optIn := g.getFileOptions(file.Desc).GetOptIn()
+	messages := []*protogen.Message{}
+	for _, message := range file.Messages {
+		// only annotated messages are generated when the file opted in
+		if optIn && !proto.HasExtension(message.Desc.Options(), protoc_gen_jsonschema.E_MessageOptions) {
+			continue
+		}
+		// only the messages reachable from generated messages are generated for the other imports
+		if !file.Generate && !g.imported[file.Desc.Path()] && !g.referenced[message.Desc.FullName()] {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// collectImported records the files of which include_imports=all generates every message: the files which the
+// requested files import, directly or through public imports, except for well-known types and custom options
+func (g *JSONSchemaGenerator) collectImported() {
+	g.imported = make(map[string]bool)
+	if *g.cfg.IncludeImports != IncludeImportsAll {
+		return
+	}
+	var visit func(imports protoreflect.FileImports, publicOnly bool)
+	visit = func(imports protoreflect.FileImports, publicOnly bool) {
+		for i := 0; i < imports.Len(); i++ {
+			file := imports.Get(i)
+			if publicOnly && !file.IsPublic || g.imported[file.Path()] {
+				continue
+			}
+			if !wellKnownFile(file) && !optionsFile(file) {
+				g.imported[file.Path()] = true
+			}
+			visit(file.Imports(), true)
+		}
+	}
+	for _, file := range g.plugin.Files {
+		if file.Generate && !g.getFileOptions(file.Desc).GetIgnore() {
+			visit(file.Desc.Imports(), false)
+		}
+	}
+}
+
+// collectReferenced records the top-level messages of imported files which are reachable from the messages of the
+// requested files and of the imports of which every message is generated
+func (g *JSONSchemaGenerator) collectReferenced() {
+	g.referenced = make(map[protoreflect.FullName]bool)
+	if *g.cfg.IncludeImports == IncludeImportsNone {
+		return
+	}
+	topLevel := make(map[protoreflect.FullName]*protogen.Message)
+	for _, file := range g.plugin.Files {
+		for _, message := range file.Messages {
+			topLevel[message.Desc.FullName()] = message
+		}
+	}
+	for _, file := range g.plugin.Files {
+		if !file.Generate && !g.imported[file.Desc.Path()] || g.getFileOptions(file.Desc).GetIgnore() {
+			continue
+		}
+		for _, message := range g.includedMessages(file) {
+			g.visitReferenced(topLevel, message)
+		}
+	}
+}
+
+// visitReferenced records the imported messages referenced by the fields of the message and of its nested messages,
+// and visits the recorded messages in turn
+func (g *JSONSchemaGenerator) visitReferenced(topLevel map[protoreflect.FullName]*protogen.Message, message *protogen.Message) {
+	if g.getMessageOptions(message.Desc).GetIgnore() {
+		return
+	}
+	for _, field := range message.Fields {
+		if field.Message == nil {
+			continue
+		}
+		if opt, ok := proto.GetExtension(field.Desc.Options(), protoc_gen_jsonschema.E_FieldOptions).(*protoc_gen_jsonschema.FieldOptions); ok && opt.GetIgnore() {
+			continue
+		}
+		target := field.Message.Desc
+		// maps are generated as blank objects, which don't reference their values
+		if target.IsMapEntry() {
+			continue
+		}
+		file := target.ParentFile()
+		if wellKnownFile(file) || g.plugin.FilesByPath[file.Path()].Generate || g.imported[file.Path()] {
+			continue
+		}
+		document := topLevelMessage(target).FullName()
+		if !g.referenced[document] {
+			g.referenced[document] = true
+			g.visitReferenced(topLevel, topLevel[document])
+		}
+	}
+	for _, nested := range message.Messages {
+		if !nested.Desc.IsMapEntry() {
+			g.visitReferenced(topLevel, nested)
+		}
+	}
+}
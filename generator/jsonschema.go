@@ -1,8 +1,12 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	protoc_gen_jsonschema "github.com/TheRebelOfBabylon/protoc-gen-jsonschema"
@@ -10,12 +14,27 @@ import (
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type JSONSchemaGenerator struct {
 	cfg               *config.Config
 	plugin            *protogen.Plugin
 	linterRulePattern *regexp.Regexp
+	resourcePatterns  map[string][]string
+	// selfContained is set while generating schemas which must carry the definitions of every message they use
+	selfContained bool
+	// documents holds the top-level messages which get a schema of their own
+	documents map[protoreflect.FullName]bool
+	// referenced holds the top-level messages of imported files which are reachable from generated messages
+	referenced map[protoreflect.FullName]bool
+	// imported holds the paths of the imported files of which every message is generated
+	imported map[string]bool
+	// unvalidated holds the validation keywords which were kept as extensions while rewriting a schema into a legacy OpenAPI dialect
+	unvalidated map[string]bool
+	// warnings receives the warnings about generated schemas. protoc prints the standard error of plugins
+	warnings io.Writer
 }
 
 // NewJSONSchemaGenerator creates a new instance of the JSONSchemaGenerator struct
@@ -24,15 +43,74 @@ func NewJSONSchemaGenerator(plugin *protogen.Plugin, cfg *config.Config) *JSONSc
 		cfg:               cfg,
 		plugin:            plugin,
 		linterRulePattern: regexp.MustCompile(`\(-- .* --\)`),
+		resourcePatterns:  collectResourcePatterns(plugin),
+		unvalidated:       make(map[string]bool),
+		warnings:          os.Stderr,
 	}
 }
 
 // Run runs the generator
 func (g *JSONSchemaGenerator) Run() error {
+	if err := g.checkOutput(); err != nil {
+		return err
+	}
+	if err := g.checkDeprecated(); err != nil {
+		return err
+	}
+	if err := g.checkDraft(); err != nil {
+		return err
+	}
+	if err := g.checkOutputFormat(); err != nil {
+		return err
+	}
+	if err := g.checkBundle(); err != nil {
+		return err
+	}
+	if err := g.checkPaths(); err != nil {
+		return err
+	}
+	if err := g.checkPropertyOrder(); err != nil {
+		return err
+	}
+	if err := g.checkServices(); err != nil {
+		return err
+	}
+	if err := g.checkIdBase(); err != nil {
+		return err
+	}
+	if err := g.checkIdTemplate(); err != nil {
+		return err
+	}
+	if err := g.checkIncludeImports(); err != nil {
+		return err
+	}
+	if g.openAPI() {
+		return g.buildOpenAPIDocuments()
+	}
+	if g.asyncAPI() {
+		return g.buildAsyncAPIDocuments()
+	}
+	g.collectImported()
+	g.collectReferenced()
+	if err := g.collectDocuments(); err != nil {
+		return err
+	}
+	if g.bundled() {
+		if err := g.buildBundles(); err != nil {
+			return err
+		}
+	}
 	for _, file := range g.plugin.Files {
-		if file.Generate {
-			err := g.buildSchemasFromMessages(file)
-			if err != nil {
+		if !g.includedFile(file) {
+			continue
+		}
+		if !g.bundled() {
+			if err := g.buildSchemasFromMessages(file); err != nil {
+				return err
+			}
+		}
+		if file.Generate && *g.cfg.Services {
+			if err := g.buildSchemasFromServices(file); err != nil {
 				return err
 			}
 		}
@@ -40,6 +118,90 @@ func (g *JSONSchemaGenerator) Run() error {
 	return nil
 }
 
+// getFileOptions returns the custom annotations of the file in which the given descriptor is declared
+func (g *JSONSchemaGenerator) getFileOptions(desc protoreflect.Descriptor) *protoc_gen_jsonschema.FileOptions {
+	if opt := proto.GetExtension(desc.ParentFile().Options(), protoc_gen_jsonschema.E_FileOptions); opt != nil {
+		if fileOpts, ok := opt.(*protoc_gen_jsonschema.FileOptions); ok {
+			return fileOpts
+		}
+	}
+	return nil
+}
+
+// getMessageOptions returns the custom annotations of the message
+func (g *JSONSchemaGenerator) getMessageOptions(desc protoreflect.MessageDescriptor) *protoc_gen_jsonschema.MessageOptions {
+	if opt := proto.GetExtension(desc.Options(), protoc_gen_jsonschema.E_MessageOptions); opt != nil {
+		if msgOpts, ok := opt.(*protoc_gen_jsonschema.MessageOptions); ok {
+			return msgOpts
+		}
+	}
+	return nil
+}
+
+// definitionName returns the name under which the given message is stored in definitions
+func (g *JSONSchemaGenerator) definitionName(message *protogen.Message) string {
+	return g.descriptorDefinitionName(message.Desc)
+}
+
+// descriptorDefinitionName returns the name under which the message with the given descriptor is stored in definitions
+func (g *JSONSchemaGenerator) descriptorDefinitionName(desc protoreflect.MessageDescriptor) string {
+	switch g.getFileOptions(desc).GetDefinitionsNaming() {
+	case protoc_gen_jsonschema.DefinitionsNaming_DEFINITIONS_NAMING_FULL_NAME:
+		return string(desc.FullName())
+	default:
+		return string(desc.Name())
+	}
+}
+
+// isDeprecated checks if the descriptor is marked with the standard deprecated option
+func (g *JSONSchemaGenerator) isDeprecated(desc protoreflect.Descriptor) bool {
+	switch opts := desc.Options().(type) {
+	case *descriptorpb.FieldOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.MessageOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.EnumOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.EnumValueOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.MethodOptions:
+		return opts.GetDeprecated()
+	case *descriptorpb.ServiceOptions:
+		return opts.GetDeprecated()
+	default:
+		return false
+	}
+}
+
+// Handlings of deprecated elements supported by the deprecated parameter
+const (
+	DeprecatedAnnotate = "annotate"
+	DeprecatedOmit     = "omit"
+)
+
+// checkDeprecated checks that the configured handling of deprecated elements is supported
+func (g *JSONSchemaGenerator) checkDeprecated() error {
+	switch *g.cfg.Deprecated {
+	case DeprecatedAnnotate, DeprecatedOmit:
+		return nil
+	default:
+		return fmt.Errorf("unsupported deprecated %q. Use %q or %q", *g.cfg.Deprecated, DeprecatedAnnotate, DeprecatedOmit)
+	}
+}
+
+// omitDeprecated checks if deprecated fields and enum values are dropped from generated schemas
+func (g *JSONSchemaGenerator) omitDeprecated() bool {
+	return *g.cfg.Deprecated == DeprecatedOmit
+}
+
+// deprecatedDescription mentions the deprecation in the description
+func (g *JSONSchemaGenerator) deprecatedDescription(description string) string {
+	if description == "" {
+		return "Deprecated."
+	}
+	return "Deprecated: " + description
+}
+
 // reformatComment reformats the protobuf comment string into a readable format
 func (g *JSONSchemaGenerator) reformatComment(c protogen.Comments) string {
 	comment := string(c)
@@ -49,10 +211,9 @@ func (g *JSONSchemaGenerator) reformatComment(c protogen.Comments) string {
 }
 
 // createSchemaFromField creates a SchemaProperty struct
-func (g *JSONSchemaGenerator) createSchemaFromField(fieldOpts *protoc_gen_jsonschema.FieldOptions, field *protogen.Field, arrayCheck bool) *SchemaProperty {
+func (g *JSONSchemaGenerator) createSchemaFromField(fieldOpts *protoc_gen_jsonschema.FieldOptions, field *protogen.Field, arrayCheck bool) (*SchemaProperty, error) {
 	propertySchema := &SchemaProperty{
 		Description: g.reformatComment(field.Comments.Leading),
-		Properties:  make(map[string]*SchemaProperty),
 	}
 	if fieldOpts != nil {
 		// check if user specified field was required
@@ -62,48 +223,38 @@ func (g *JSONSchemaGenerator) createSchemaFromField(fieldOpts *protoc_gen_jsonsc
 		// check if user specified field has a reference
 		if ref := fieldOpts.GetRef(); ref != "" {
 			propertySchema.Ref = ref
-			return propertySchema
-		}
-		// check if user specified field has min length
-		if minLength := fieldOpts.GetMinLength(); minLength != 0 {
-			propertySchema.MinLength = minLength
-		}
-		// check if user specified field has max length
-		if maxLength := fieldOpts.GetMaxLength(); maxLength != 0 {
-			propertySchema.MaxLength = maxLength
-		}
-		// check if user specified field has min items
-		if minItems := fieldOpts.GetMinItems(); minItems != 0 {
-			propertySchema.MinItems = minItems
-		}
-		// check if user specified field has pattern
-		if pattern := fieldOpts.GetPattern(); pattern != "" {
-			propertySchema.Pattern = pattern
-		}
-		// check if user specified field has format
-		if format := fieldOpts.GetFormat(); format != "" {
-			propertySchema.Format = format
+			return propertySchema, nil
 		}
 	}
 	// check for repeated key word
 	if arrayCheck && field.Desc.IsList() {
 		propertySchema.Type = "array"
-		propertySchema.Items = g.createSchemaFromField(nil, field, false)
+		items, err := g.createSchemaFromField(nil, field, false)
+		if err != nil {
+			return nil, err
+		}
+		propertySchema.Items = items
 		if propertySchema.Items.IsRef {
 			propertySchema.IsRef = true
 		}
-		return propertySchema
+		return propertySchema, nil
 	} else if field.Desc.IsMap() {
 		// maps will just be blank objects
 		propertySchema.Type = "object"
-		return propertySchema
+		return propertySchema, nil
 	}
 	// check type of field
 	switch field.Desc.Kind() {
 	case protoreflect.BoolKind:
 		propertySchema.Type = "boolean"
-	case protoreflect.StringKind, protoreflect.BytesKind:
+	case protoreflect.StringKind:
 		propertySchema.Type = "string"
+	case protoreflect.BytesKind:
+		propertySchema.Type = "string"
+		// OpenAPI 3.0 and Swagger 2.0 name base64 encoded strings
+		if g.legacyOpenAPI() {
+			propertySchema.Format = "byte"
+		}
 	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
 		propertySchema.Type = "integer"
 		propertySchema.Format = "int32"
@@ -130,115 +281,522 @@ func (g *JSONSchemaGenerator) createSchemaFromField(fieldOpts *protoc_gen_jsonsc
 			propertySchema.Type = "object"
 		case "google.protobuf.Any":
 			propertySchema.Type = "object"
-			propertySchema.Properties["@type"] = &SchemaProperty{Type: "string"}
-			propertySchema.Properties["value"] = &SchemaProperty{Type: "string"}
+			propertySchema.Properties.Set("@type", &SchemaProperty{Type: "string"})
+			propertySchema.Properties.Set("value", &SchemaProperty{Type: "string"})
 			propertySchema.Required = append(propertySchema.Required, []string{"@type", "value"}...)
 		case "google.protobuf.Empty":
-			return nil
+			return nil, nil
 		case "google.protobuf.Timestamp":
 			propertySchema.Type = "string"
 			propertySchema.Format = "date-time"
+		case "google.protobuf.Duration":
+			propertySchema.Type = "string"
+			propertySchema.Pattern = `^-?[0-9]+(\.[0-9]{1,9})?s$`
+		case "google.protobuf.FieldMask":
+			propertySchema.Type = "string"
+		case "google.protobuf.Value":
+			// any JSON value is accepted
+		case "google.protobuf.ListValue":
+			propertySchema.Type = "array"
+		case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+			"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+			"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+			"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+			// wrappers are written as the scalar they wrap
+			value, err := g.createSchemaFromField(nil, field.Message.Fields[0], false)
+			if err != nil {
+				return nil, err
+			}
+			propertySchema.Type, propertySchema.Format = value.Type, value.Format
 		default:
 			if !arrayCheck {
 				// this is the definition of the item so we don't want a redundant description
 				propertySchema.Description = ""
 			}
-			propertySchema.Ref = fmt.Sprintf("#/definitions/%v", field.Message.Desc.Name())
-			if !*g.cfg.RepeatedDefs {
-				propertySchema.Ref = fmt.Sprintf("%v.json", field.Message.Desc.Name())
+			propertySchema.Ref = g.definitionsRef(g.definitionName(field.Message))
+			if !*g.cfg.RepeatedDefs && !g.bundled() {
+				ref, err := g.messageRef(field.Parent, field.Message)
+				if err != nil {
+					return nil, err
+				}
+				propertySchema.Ref = ref
 			}
 			propertySchema.IsRef = true
 		}
 	case protoreflect.EnumKind:
+		// google.protobuf.NullValue is written as null
+		if field.Enum.Desc.FullName() == "google.protobuf.NullValue" {
+			propertySchema.Type = "null"
+			break
+		}
 		propertySchema.Type = "string"
+		deprecatedValues := false
 		for _, value := range field.Enum.Values {
+			if g.isDeprecated(value.Desc) {
+				if g.omitDeprecated() {
+					continue
+				}
+				deprecatedValues = true
+			}
 			propertySchema.Enum = append(propertySchema.Enum, string(value.Desc.Name()))
 		}
+		// the enum keyword can't annotate its values, so each value becomes a constant
+		if deprecatedValues {
+			propertySchema.Enum = nil
+			for _, value := range field.Enum.Values {
+				propertySchema.AnyOf = append(propertySchema.AnyOf, &SchemaProperty{
+					Const:      string(value.Desc.Name()),
+					Deprecated: g.isDeprecated(value.Desc),
+				})
+			}
+		}
 	default:
-		return nil
+		return nil, nil
+	}
+	return propertySchema, nil
+}
+
+// applyFieldOptions sets the keywords constraining the field from its custom annotations
+func (g *JSONSchemaGenerator) applyFieldOptions(fieldOpts *protoc_gen_jsonschema.FieldOptions, propertySchema *SchemaProperty) {
+	// a user specified reference replaces the whole schema
+	if fieldOpts == nil || fieldOpts.GetRef() != "" {
+		return
+	}
+	// check if user specified field has min length
+	if minLength := fieldOpts.GetMinLength(); minLength != 0 {
+		propertySchema.MinLength = minLength
+	}
+	// check if user specified field has max length
+	if maxLength := fieldOpts.GetMaxLength(); maxLength != 0 {
+		propertySchema.MaxLength = maxLength
+	}
+	// check if user specified field has min items
+	if minItems := fieldOpts.GetMinItems(); minItems != 0 {
+		propertySchema.MinItems = minItems
+	}
+	// check if user specified field has pattern
+	if pattern := fieldOpts.GetPattern(); pattern != "" {
+		propertySchema.Pattern = pattern
+	}
+	// check if user specified field has format
+	if format := fieldOpts.GetFormat(); format != "" {
+		propertySchema.Format = format
+	}
+	// check if user specified field is read only
+	if fieldOpts.GetReadOnly() {
+		propertySchema.ReadOnly = true
+	}
+	// check if user specified field is write only
+	if fieldOpts.GetWriteOnly() {
+		propertySchema.WriteOnly = true
 	}
-	return propertySchema
 }
 
 // parseField parses a given protobuf field and creates a SchemaProperty struct
-func (g *JSONSchemaGenerator) parseField(field *protogen.Field) *SchemaProperty {
+func (g *JSONSchemaGenerator) parseField(field *protogen.Field) (*SchemaProperty, error) {
+	var fieldOpts *protoc_gen_jsonschema.FieldOptions
 	// check custom annotations
 	if opt := proto.GetExtension(field.Desc.Options(), protoc_gen_jsonschema.E_FieldOptions); opt != nil {
-		if fieldOpts, ok := opt.(*protoc_gen_jsonschema.FieldOptions); ok {
+		if opts, ok := opt.(*protoc_gen_jsonschema.FieldOptions); ok {
 			// If we're ignoring it, return nil
-			if fieldOpts.GetIgnore() {
-				return nil
+			if opts.GetIgnore() {
+				return nil, nil
 			}
-			return g.createSchemaFromField(fieldOpts, field, true)
+			fieldOpts = opts
+		}
+	}
+	deprecated := g.isDeprecated(field.Desc) || (field.Enum != nil && g.isDeprecated(field.Enum.Desc))
+	if deprecated && g.omitDeprecated() {
+		return nil, nil
+	}
+	propertySchema, err := g.createSchemaFromField(fieldOpts, field, true)
+	if err != nil || propertySchema == nil {
+		return nil, err
+	}
+	// annotations of other plugins are applied first so that custom annotations take precedence over them
+	g.applyOpenAPIv2Field(field, propertySchema)
+	g.applyOpenAPIv3Property(field, propertySchema)
+	g.applyProtovalidate(field, propertySchema)
+	g.applyValidateRules(field, propertySchema)
+	g.applyFieldBehavior(field, propertySchema)
+	g.applyResourceReference(field, propertySchema)
+	g.applyFieldOptions(fieldOpts, propertySchema)
+	// the deprecation is mentioned once the annotations have set the description
+	if deprecated {
+		propertySchema.Deprecated = true
+		propertySchema.Description = g.deprecatedDescription(propertySchema.Description)
+	}
+	// extra keywords are merged last, see mergeExtra
+	if extra, extraStruct := fieldOpts.GetExtra(), fieldOpts.GetExtraStruct(); extra != "" || extraStruct != nil {
+		extraKeywords, err := g.mergeExtra(extra, extraStruct, propertySchema, propertySchema.Extra)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extra keywords for field %s: %w", field.Desc.FullName(), err)
+		}
+		propertySchema.Extra = extraKeywords
+	}
+	if opts, ok := field.Desc.Options().(*descriptorpb.FieldOptions); ok && opts.GetDebugRedact() {
+		g.redactProperty(propertySchema)
+	}
+	return propertySchema, nil
+}
+
+// redactProperty marks the property as sensitive and drops any value that could leak a secret into published docs
+func (g *JSONSchemaGenerator) redactProperty(propertySchema *SchemaProperty) {
+	propertySchema.WriteOnly = true
+	propertySchema.Default = nil
+	propertySchema.Examples = nil
+	if propertySchema.Items != nil {
+		propertySchema.Items.Default = nil
+		propertySchema.Items.Examples = nil
+	}
+	if propertySchema.Extra == nil {
+		propertySchema.Extra = make(map[string]interface{})
+	}
+	delete(propertySchema.Extra, "default")
+	delete(propertySchema.Extra, "examples")
+	delete(propertySchema.Extra, "example")
+	propertySchema.Extra["x-sensitive"] = true
+}
+
+// mergeExtra parses the given JSON object text or struct, checks that none of its keywords are already generated in schema and merges them into keywords,
+// the extensions of schema. Extra keywords are merged last so that they can be checked against every generated keyword, and applyDraft checks them again
+// against the keywords rewritten for the configured draft. They override extensions set by other annotations, such as those of OpenAPI annotations
+func (g *JSONSchemaGenerator) mergeExtra(extra string, extraStruct *structpb.Struct, schema interface{}, keywords map[string]interface{}) (map[string]interface{}, error) {
+	extraKeywords := make(map[string]interface{})
+	if extraStruct != nil {
+		extraKeywords = extraStruct.AsMap()
+	} else if err := json.Unmarshal([]byte(extra), &extraKeywords); err != nil {
+		return nil, err
+	}
+	// extensions are serialized along with the generated keywords, so the keywords they set are not checked
+	checked := make(map[string]interface{})
+	for keyword, value := range extraKeywords {
+		if _, ok := keywords[keyword]; !ok {
+			checked[keyword] = value
+		}
+	}
+	if err := g.checkExtra(schema, checked); err != nil {
+		return nil, err
+	}
+	if keywords == nil {
+		keywords = make(map[string]interface{})
+	}
+	for keyword, value := range extraKeywords {
+		keywords[keyword] = value
+	}
+	return keywords, nil
+}
+
+// checkExtra checks that none of the extra keywords are already generated in schema
+func (g *JSONSchemaGenerator) checkExtra(schema interface{}, extra map[string]interface{}) error {
+	if len(extra) == 0 {
+		return nil
+	}
+	generated, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	generatedKeywords := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(generated, &generatedKeywords); err != nil {
+		return err
+	}
+	for keyword := range extra {
+		if _, ok := generatedKeywords[keyword]; ok {
+			return fmt.Errorf("keyword %q collides with a generated keyword", keyword)
 		}
 	}
-	return g.createSchemaFromField(nil, field, true)
+	return nil
 }
 
 // createSchemaFromMessage creates a Schema struct
-func (g *JSONSchemaGenerator) createSchemaFromMessage(msgOpts *protoc_gen_jsonschema.MessageOptions, message *protogen.Message, schema *Schema) *Schema {
+func (g *JSONSchemaGenerator) createSchemaFromMessage(msgOpts *protoc_gen_jsonschema.MessageOptions, message *protogen.Message, schema *Schema) (*Schema, error) {
 	if schema == nil {
+		fileOpts := g.getFileOptions(message.Desc)
 		schema = NewSchema(
-			fmt.Sprintf("%v.json", message.Desc.Name()),
-			string(message.Desc.Name()),
+			g.messageId(message.Desc),
+			fileOpts.GetTitlePrefix()+string(message.Desc.Name()),
 			g.reformatComment(message.Comments.Leading),
 			"object",
 		)
 	}
-	if msgOpts != nil {
-		// get Id annotion
-		if newId := msgOpts.GetId(); newId != "" {
-			schema.Id = newId
-		}
-	}
-	for _, field := range message.Fields {
+	g.setAdditionalProperties(msgOpts, message, schema)
+	for _, field := range g.orderedFields(message) {
 		// parse the field as a property
-		if parsedField := g.parseField(field); parsedField != nil {
-			schema.Properties[field.Desc.JSONName()] = parsedField
+		parsedField, err := g.parseField(field)
+		if err != nil {
+			return nil, err
+		}
+		if parsedField != nil {
+			schema.Properties.Set(field.Desc.JSONName(), parsedField)
 			if parsedField.IsRequired {
-				schema.Required = append(schema.Required, field.Desc.JSONName())
+				schema.Required = appendUnique(schema.Required, field.Desc.JSONName())
 			}
-			// check if new field is a reference. Add to map of definitions IF cfg allows
-			if parsedField.IsRef && *g.cfg.RepeatedDefs {
-				newDefs := g.parseMessage(
+			// check if new field is a reference. Add to map of definitions IF cfg allows. Bundled documents register
+			// referenced messages once in their shared definitions instead
+			if parsedField.IsRef && *g.cfg.RepeatedDefs && !g.bundled() {
+				newDefs, err := g.parseMessage(
 					field.Message,
 					&Schema{
 						Type:        "object",
 						Description: g.reformatComment(field.Message.Comments.Leading),
-						Properties:  make(map[string]*SchemaProperty),
 						Definitions: make(map[string]*Schema),
 					},
 				)
+				if err != nil {
+					return nil, err
+				}
 				// append all nested defs to this schema
 				for name, newDef := range newDefs.Definitions {
 					schema.Definitions[name] = newDef
 				}
 				// erase the nested defs from the new def and append
 				newDefs.Definitions = make(map[string]*Schema)
-				schema.Definitions[string(field.Message.Desc.Name())] = newDefs
+				schema.Definitions[g.definitionName(field.Message)] = newDefs
 			}
 		}
 
 	}
+	// message annotations are applied once the properties exist, as the required fields they list must have one
+	if err := g.applyOpenAPIv2Schema(message, schema); err != nil {
+		return nil, err
+	}
+	if err := g.applyOpenAPIv3Schema(message, schema); err != nil {
+		return nil, err
+	}
+	if g.isDeprecated(message.Desc) {
+		schema.Deprecated = true
+		schema.Description = g.deprecatedDescription(schema.Description)
+	}
+	if msgOpts != nil {
+		// get Id annotion
+		if newId := msgOpts.GetId(); newId != "" {
+			schema.Id = newId
+		}
+		// check if user specified message has min properties
+		if minProperties := msgOpts.GetMinProperties(); minProperties != 0 {
+			schema.MinProperties = minProperties
+		}
+		// check if user specified message has max properties
+		if maxProperties := msgOpts.GetMaxProperties(); maxProperties != 0 {
+			schema.MaxProperties = maxProperties
+		}
+	}
 	// Override required parameter if stipulated in protobuf message definition
 	if msgOpts != nil && msgOpts.GetAllFieldsRequired() {
+		// only the generated properties can be required, as ignored or omitted fields are forbidden in strict mode
 		allFieldsRequired := []string{}
-		for _, field := range message.Fields {
-			allFieldsRequired = append(allFieldsRequired, field.Desc.JSONName())
+		for _, property := range schema.Properties {
+			allFieldsRequired = append(allFieldsRequired, property.Name)
 		}
 		schema.Required = allFieldsRequired[:]
 	}
-	return schema
+	schema.Required = g.orderRequired(message, schema.Required)
+	if msgOpts != nil {
+		if err := g.setConditions(msgOpts.GetConditions(), message, schema); err != nil {
+			return nil, err
+		}
+		if err := g.setFieldGroups(msgOpts, message, schema); err != nil {
+			return nil, err
+		}
+		// extra keywords are merged last, see mergeExtra
+		if extra, extraStruct := msgOpts.GetExtra(), msgOpts.GetExtraStruct(); extra != "" || extraStruct != nil {
+			extraKeywords, err := g.mergeExtra(extra, extraStruct, schema, schema.Extra)
+			if err != nil {
+				return nil, fmt.Errorf("invalid extra keywords for message %s: %w", message.Desc.FullName(), err)
+			}
+			schema.Extra = extraKeywords
+		}
+	}
+	return schema, nil
+}
+
+// setAdditionalProperties sets the additionalProperties keyword from the strict parameter or the message annotation
+func (g *JSONSchemaGenerator) setAdditionalProperties(msgOpts *protoc_gen_jsonschema.MessageOptions, message *protogen.Message, schema *Schema) {
+	strict := *g.cfg.Strict
+	// file annotation overrides the strict parameter
+	if fileOpts := g.getFileOptions(message.Desc); fileOpts != nil && fileOpts.Strict != nil {
+		strict = fileOpts.GetStrict()
+	}
+	if strict {
+		schema.AdditionalProperties = false
+	}
+	if msgOpts != nil && msgOpts.GetAdditionalProperties() != nil {
+		switch value := msgOpts.GetAdditionalProperties().GetValue().(type) {
+		case *protoc_gen_jsonschema.AdditionalProperties_Allow:
+			schema.AdditionalProperties = value.Allow
+		case *protoc_gen_jsonschema.AdditionalProperties_Ref:
+			schema.AdditionalProperties = &SchemaProperty{Ref: value.Ref}
+		}
+	}
+	// protojson writes extension fields with "[full.name]" keys, so those must stay valid when extra keys are restricted
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties != true && message.Desc.ExtensionRanges().Len() > 0 {
+		schema.PatternProperties = map[string]*SchemaProperty{`^\[.+\]$`: {}}
+	}
+}
+
+// appendUnique appends the value to the list unless it is already in it
+func appendUnique(list []string, value string) []string {
+	for _, item := range list {
+		if item == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// lookupField returns the field of the message with the given proto or JSON name
+func (g *JSONSchemaGenerator) lookupField(message *protogen.Message, name string) *protogen.Field {
+	for _, field := range message.Fields {
+		if string(field.Desc.Name()) == name || field.Desc.JSONName() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// lookupProperty returns the field of the message with the given proto or JSON name, which must have a property in
+// the schema. Ignored or omitted fields have none, so keywords naming them could never be satisfied in strict mode
+func (g *JSONSchemaGenerator) lookupProperty(message *protogen.Message, schema *Schema, name string) (*protogen.Field, error) {
+	field := g.lookupField(message, name)
+	if field == nil {
+		return nil, fmt.Errorf("message %s has no field named %q", message.Desc.FullName(), name)
+	}
+	if schema.Properties.Get(field.Desc.JSONName()) == nil {
+		return nil, fmt.Errorf("field %s is ignored or omitted, so it has no property in the schema of %s", field.Desc.FullName(), message.Desc.FullName())
+	}
+	return field, nil
+}
+
+// lookupPropertyNames converts the given field names of the message into the names of their properties in the schema
+func (g *JSONSchemaGenerator) lookupPropertyNames(message *protogen.Message, schema *Schema, names []string) ([]string, error) {
+	propertyNames := []string{}
+	for _, name := range names {
+		field, err := g.lookupProperty(message, schema, name)
+		if err != nil {
+			return nil, err
+		}
+		propertyNames = append(propertyNames, field.Desc.JSONName())
+	}
+	return propertyNames, nil
+}
+
+// constValue converts the string representation of a field value into a value matching the field JSON type
+func (g *JSONSchemaGenerator) constValue(field *protogen.Field, value string) (interface{}, error) {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.ParseBool(value)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.ParseInt(value, 10, 64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.ParseUint(value, 10, 64)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return strconv.ParseFloat(value, 64)
+	case protoreflect.EnumKind:
+		for _, enumValue := range field.Enum.Values {
+			if string(enumValue.Desc.Name()) == value {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("enum %s has no value named %q", field.Enum.Desc.FullName(), value)
+	default:
+		return value, nil
+	}
+}
+
+// setConditions converts the condition annotations of the message into if/then/else keywords
+func (g *JSONSchemaGenerator) setConditions(conditions []*protoc_gen_jsonschema.Condition, message *protogen.Message, schema *Schema) error {
+	conditionals := []*SchemaProperty{}
+	for _, condition := range conditions {
+		field, err := g.lookupProperty(message, schema, condition.GetIfField())
+		if err != nil {
+			return err
+		}
+		value, err := g.constValue(field, condition.GetIfValue())
+		if err != nil {
+			return fmt.Errorf("invalid condition value for field %s: %w", field.Desc.FullName(), err)
+		}
+		conditional := &SchemaProperty{
+			If: &SchemaProperty{
+				Properties: Properties{{Name: field.Desc.JSONName(), Property: &SchemaProperty{Const: value}}},
+				Required:   []string{field.Desc.JSONName()},
+			},
+		}
+		if len(condition.GetThenRequired()) > 0 {
+			thenRequired, err := g.lookupPropertyNames(message, schema, condition.GetThenRequired())
+			if err != nil {
+				return err
+			}
+			conditional.Then = &SchemaProperty{Required: thenRequired}
+		}
+		if len(condition.GetElseRequired()) > 0 {
+			elseRequired, err := g.lookupPropertyNames(message, schema, condition.GetElseRequired())
+			if err != nil {
+				return err
+			}
+			conditional.Else = &SchemaProperty{Required: elseRequired}
+		}
+		conditionals = append(conditionals, conditional)
+	}
+	// a single condition doesn't need to be wrapped in allOf
+	if len(conditionals) == 1 {
+		schema.If, schema.Then, schema.Else = conditionals[0].If, conditionals[0].Then, conditionals[0].Else
+		return nil
+	}
+	schema.AllOf = append(schema.AllOf, conditionals...)
+	return nil
+}
+
+// setFieldGroups converts the dependent required, mutually exclusive and at least one of annotations of the message into keywords
+func (g *JSONSchemaGenerator) setFieldGroups(msgOpts *protoc_gen_jsonschema.MessageOptions, message *protogen.Message, schema *Schema) error {
+	for _, dependentRequired := range msgOpts.GetDependentRequired() {
+		field, err := g.lookupProperty(message, schema, dependentRequired.GetField())
+		if err != nil {
+			return err
+		}
+		required, err := g.lookupPropertyNames(message, schema, dependentRequired.GetRequired())
+		if err != nil {
+			return err
+		}
+		if schema.Dependencies == nil {
+			schema.Dependencies = make(map[string][]string)
+		}
+		schema.Dependencies[field.Desc.JSONName()] = append(schema.Dependencies[field.Desc.JSONName()], required...)
+	}
+	for _, group := range msgOpts.GetMutuallyExclusive() {
+		fields, err := g.lookupPropertyNames(message, schema, group.GetFields())
+		if err != nil {
+			return err
+		}
+		// no two fields of the group may be present together
+		for i := range fields {
+			for j := i + 1; j < len(fields); j++ {
+				schema.AllOf = append(schema.AllOf, &SchemaProperty{Not: &SchemaProperty{Required: []string{fields[i], fields[j]}}})
+			}
+		}
+	}
+	for _, group := range msgOpts.GetAtLeastOneOf() {
+		fields, err := g.lookupPropertyNames(message, schema, group.GetFields())
+		if err != nil {
+			return err
+		}
+		atLeastOneOf := &SchemaProperty{}
+		for _, field := range fields {
+			atLeastOneOf.AnyOf = append(atLeastOneOf.AnyOf, &SchemaProperty{Required: []string{field}})
+		}
+		schema.AllOf = append(schema.AllOf, atLeastOneOf)
+	}
+	return nil
 }
 
 // parseMessage will parse the protobuf Message definition and populate the Schema struct
-func (g *JSONSchemaGenerator) parseMessage(message *protogen.Message, schema *Schema) *Schema {
+func (g *JSONSchemaGenerator) parseMessage(message *protogen.Message, schema *Schema) (*Schema, error) {
 	// check custom annotations
 	if opt := proto.GetExtension(message.Desc.Options(), protoc_gen_jsonschema.E_MessageOptions); opt != nil {
 		if msgOpts, ok := opt.(*protoc_gen_jsonschema.MessageOptions); ok {
 			// If we're ignoring it, return nil
 			if msgOpts.GetIgnore() {
-				return nil
+				return nil, nil
 			}
 			return g.createSchemaFromMessage(msgOpts, message, schema)
 		}
@@ -248,11 +806,23 @@ func (g *JSONSchemaGenerator) parseMessage(message *protogen.Message, schema *Sc
 
 // buildSchemasFromMessages builds the JSON schema files from the messages inside the protobuf definition file
 func (g *JSONSchemaGenerator) buildSchemasFromMessages(file *protogen.File) error {
-	for _, message := range file.Messages {
-		schema := g.parseMessage(message, nil)
+	for _, message := range g.includedMessages(file) {
+		schema, err := g.parseMessage(message, nil)
+		if err != nil {
+			return err
+		}
 		if schema != nil {
-			outputFile := g.plugin.NewGeneratedFile(fmt.Sprintf("%s.json", message.Desc.Name()), "")
-			outputFile.Write(schema.Json())
+			if !*g.cfg.RepeatedDefs {
+				if err := g.addNestedDefinitions(message, schema); err != nil {
+					return err
+				}
+			}
+			if err := g.applyDraft(schema); err != nil {
+				return fmt.Errorf("invalid extra keywords for message %s: %w", message.Desc.FullName(), err)
+			}
+			if err := g.writeFile(g.outputPath(file.Desc, string(message.Desc.Name())), schema.Json()); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
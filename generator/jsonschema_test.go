@@ -0,0 +1,464 @@
+package generator
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/TheRebelOfBabylon/protoc-gen-jsonschema/config"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// goldenTest runs the generator on a fixture and compares the generated files with testdata/golden/<name>
+type goldenTest struct {
+	name string
+	// fixture is the name of a FileDescriptorSet in text format in testdata, without the .textpb extension
+	fixture string
+	// params are the plugin parameters, e.g. "strict=true,draft=2020-12"
+	params string
+	// generate lists the files to generate. Defaults to every file of the fixture
+	generate []string
+	// err is a substring of the expected error. No golden files are compared when set
+	err string
+	// warnings are the expected warnings, one per line
+	warnings []string
+}
+
+// loadFixture reads the files of the fixture, preceded by the files they import from the registry. It also returns
+// the names of the files of the fixture itself
+func loadFixture(t *testing.T, fixture string) ([]*descriptorpb.FileDescriptorProto, []string) {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", fixture+".textpb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := (prototext.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}).Unmarshal(content, set); err != nil {
+		t.Fatalf("invalid fixture %s: %v", fixture, err)
+	}
+	inSet := make(map[string]bool)
+	names := []string{}
+	for _, file := range set.GetFile() {
+		inSet[file.GetName()] = true
+		names = append(names, file.GetName())
+	}
+	seen := make(map[string]bool)
+	files := []*descriptorpb.FileDescriptorProto{}
+	var addImports func(path string)
+	addImports = func(path string) {
+		if inSet[path] || seen[path] {
+			return
+		}
+		seen[path] = true
+		file, err := protoregistry.GlobalFiles.FindFileByPath(path)
+		if err != nil {
+			t.Fatalf("fixture %s imports unknown file %s", fixture, path)
+		}
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			addImports(imports.Get(i).Path())
+		}
+		files = append(files, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, file := range set.GetFile() {
+		for _, dependency := range file.GetDependency() {
+			addImports(dependency)
+		}
+	}
+	return append(files, set.GetFile()...), names
+}
+
+// runGenerator runs the generator with the given parameters and returns the generated files by name and the warnings
+func runGenerator(t *testing.T, test goldenTest) (map[string]string, []string, error) {
+	t.Helper()
+	files, generate := loadFixture(t, test.fixture)
+	if test.generate != nil {
+		generate = test.generate
+	}
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: generate,
+		ProtoFile:      files,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := &strings.Builder{}
+	generator := NewJSONSchemaGenerator(plugin, newTestConfig(t, test.params))
+	generator.warnings = warnings
+	if err := generator.Run(); err != nil {
+		return nil, nil, err
+	}
+	generated := make(map[string]string)
+	for _, file := range plugin.Response().GetFile() {
+		generated[file.GetName()] = file.GetContent()
+	}
+	lines := []string{}
+	if warnings.Len() > 0 {
+		lines = strings.Split(strings.TrimSuffix(warnings.String(), "\n"), "\n")
+	}
+	return generated, lines, nil
+}
+
+// testGolden runs the golden tests. Run "go test ./generator -update" to rewrite the golden files
+func testGolden(t *testing.T, tests []goldenTest) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			generated, warnings, err := runGenerator(t, test)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(warnings, "\n") != strings.Join(test.warnings, "\n") {
+				t.Errorf("expected warnings %q, got %q", test.warnings, warnings)
+			}
+			dir := filepath.Join("testdata", "golden", test.name)
+			if *update {
+				if err := os.RemoveAll(dir); err != nil {
+					t.Fatal(err)
+				}
+				for name, content := range generated {
+					path := filepath.Join(dir, filepath.FromSlash(name))
+					if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return
+			}
+			golden := make(map[string]string)
+			err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil || info.IsDir() {
+					return err
+				}
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				name, err := filepath.Rel(dir, path)
+				golden[filepath.ToSlash(name)] = string(content)
+				return err
+			})
+			if err != nil {
+				t.Fatalf("missing golden files, run with -update: %v", err)
+			}
+			for _, name := range sortedKeys(generated) {
+				if want, ok := golden[name]; !ok {
+					t.Errorf("unexpected file %s", name)
+				} else if generated[name] != want {
+					t.Errorf("file %s differs from its golden file:\n%s", name, generated[name])
+				}
+			}
+			for _, name := range sortedKeys(golden) {
+				if _, ok := generated[name]; !ok {
+					t.Errorf("missing file %s", name)
+				}
+			}
+		})
+	}
+}
+
+// sortedKeys returns the keys of the map in sorted order
+func sortedKeys(files map[string]string) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupTestMessage returns the message with the given full name from the files of the fixture
+func lookupTestMessage(t *testing.T, fixture string, fullName protoreflect.FullName) *protogen.Message {
+	t.Helper()
+	files, _ := loadFixture(t, fixture)
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{ProtoFile: files})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var find func(messages []*protogen.Message) *protogen.Message
+	find = func(messages []*protogen.Message) *protogen.Message {
+		for _, message := range messages {
+			if message.Desc.FullName() == fullName {
+				return message
+			}
+			if nested := find(message.Messages); nested != nil {
+				return nested
+			}
+		}
+		return nil
+	}
+	for _, file := range plugin.Files {
+		if message := find(file.Messages); message != nil {
+			return message
+		}
+	}
+	t.Fatalf("fixture %s has no message %s", fixture, fullName)
+	return nil
+}
+
+// newTestConfig creates a Config struct with the given plugin parameters
+func newTestConfig(t *testing.T, params string) *config.Config {
+	t.Helper()
+	flags := flag.NewFlagSet(t.Name(), flag.ContinueOnError)
+	cfg := config.NewConfig(flags)
+	if params != "" {
+		for _, param := range strings.Split(params, ",") {
+			name, value, _ := strings.Cut(param, "=")
+			if err := flags.Set(name, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return cfg
+}
+
+// newTestGenerator creates a generator with the given plugin parameters and no files
+func newTestGenerator(t *testing.T, params string) *JSONSchemaGenerator {
+	t.Helper()
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewJSONSchemaGenerator(plugin, newTestConfig(t, params))
+}
+
+func TestStrict(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "strict", fixture: "strict", params: "strict=true"},
+		{name: "additional_properties", fixture: "strict"},
+	})
+}
+
+func TestFileOptions(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "file_options", fixture: "file_options"},
+	})
+}
+
+func TestConditions(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "conditions", fixture: "conditions", generate: []string{"conditions.proto"}},
+		{name: "conditions_draft04", fixture: "conditions", params: "draft=04", generate: []string{"conditions.proto"}},
+		{name: "conditions_unknown", fixture: "conditions", generate: []string{"conditions_unknown.proto"}, err: `has no field named "missing"`},
+		{name: "conditions_ignored", fixture: "conditions", generate: []string{"conditions_ignored.proto"}, err: "is ignored or omitted"},
+	})
+}
+
+func TestFieldGroups(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "field_groups", fixture: "field_groups", generate: []string{"field_groups.proto"}},
+		{name: "field_groups_2020_12", fixture: "field_groups", params: "draft=2020-12", generate: []string{"field_groups.proto"}},
+		{name: "field_groups_ignored", fixture: "field_groups", generate: []string{"field_groups_ignored.proto"}, err: "is ignored or omitted"},
+	})
+}
+
+func TestExtra(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "extra", fixture: "extra", generate: []string{"extra.proto"}},
+		{name: "extra_2020_12", fixture: "extra", params: "draft=2020-12", generate: []string{"extra.proto"}},
+		{name: "extra_collision", fixture: "extra", generate: []string{"extra_collision.proto"}, err: `keyword "type" collides with a generated keyword`},
+		{name: "extra_invalid", fixture: "extra", generate: []string{"extra_invalid.proto"}, err: "invalid extra keywords for message extra.invalid.Invalid"},
+	})
+}
+
+func TestProtovalidate(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "protovalidate", fixture: "protovalidate"},
+		{name: "protovalidate_draft04", fixture: "protovalidate", params: "draft=04"},
+	})
+}
+
+func TestValidateRules(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "pgv", fixture: "pgv"},
+	})
+}
+
+func TestFieldBehavior(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "field_behavior", fixture: "field_behavior"},
+	})
+}
+
+func TestResourceReference(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "resources", fixture: "resources"},
+	})
+}
+
+func TestOpenAPIv2Annotations(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "openapiv2", fixture: "openapiv2", generate: []string{"openapiv2.proto"}},
+		{name: "openapiv2_unknown", fixture: "openapiv2", generate: []string{"openapiv2_unknown.proto"}, err: `has no field named "missing"`},
+		{name: "openapiv2_ignored", fixture: "openapiv2", generate: []string{"openapiv2_ignored.proto"}, err: "is ignored or omitted"},
+	})
+}
+
+func TestOpenAPIv3Annotations(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "gnostic", fixture: "gnostic", generate: []string{"gnostic.proto"}},
+		{name: "gnostic_unknown", fixture: "gnostic", generate: []string{"gnostic_unknown.proto"}, err: `has no field named "missing"`},
+		{name: "gnostic_omitted", fixture: "gnostic", params: "deprecated=omit", generate: []string{"gnostic_omitted.proto"}, err: "is ignored or omitted"},
+	})
+}
+
+func TestDeprecated(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "deprecated_annotate", fixture: "deprecated"},
+		{name: "deprecated_omit", fixture: "deprecated", params: "deprecated=omit"},
+		{name: "deprecated_unsupported", fixture: "deprecated", params: "deprecated=drop", err: `unsupported deprecated "drop"`},
+	})
+}
+
+func TestReadWrite(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "read_write", fixture: "read_write"},
+		{name: "read_write_swagger20", fixture: "read_write", params: "output=swagger20"},
+	})
+}
+
+func TestDrafts(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "draft04", fixture: "drafts", params: "draft=04"},
+		{name: "draft07", fixture: "drafts", params: "draft=07"},
+		{name: "draft2019_09", fixture: "drafts", params: "draft=2019-09"},
+		{name: "draft2020_12", fixture: "drafts", params: "draft=2020-12"},
+		{name: "draft_unsupported", fixture: "drafts", params: "draft=06", err: `unsupported draft "06"`},
+	})
+}
+
+func TestOpenAPI(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "openapi31", fixture: "openapi", params: "output=openapi31", generate: []string{"library.proto"}},
+		{
+			name:     "openapi30",
+			fixture:  "openapi",
+			params:   "output=openapi30",
+			generate: []string{"library.proto"},
+			warnings: []string{
+				`warning: openapi30 can't express the "propertyNames" keywords of schema Book, which are kept as extensions and not validated`,
+			},
+		},
+		{
+			name:     "swagger20",
+			fixture:  "openapi",
+			params:   "output=swagger20",
+			generate: []string{"library.proto"},
+			warnings: []string{
+				`warning: swagger20 can't express the "anyOf", "not", "propertyNames" keywords of schema Book, which are kept as extensions and not validated`,
+			},
+		},
+		{name: "openapi_ignored_body", fixture: "openapi", params: "output=openapi31", generate: []string{"ignored_body.proto"}, err: "message library.ignored.Secret is used by an HTTP body but is ignored"},
+		{name: "openapi_duplicate_operation", fixture: "openapi", params: "output=openapi31", generate: []string{"duplicate_operation.proto"}, err: "operations ShelfService_GetShelf and ShelfService_FindShelf both map to GET /v1/{name}"},
+	})
+}
+
+func TestOutputFormat(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "yaml", fixture: "drafts", params: "output_format=yaml"},
+		{name: "yml_openapi31", fixture: "openapi", params: "output=openapi31,output_format=yml", generate: []string{"library.proto"}},
+		{name: "output_format_unsupported", fixture: "drafts", params: "output_format=toml", err: `unsupported output format "toml"`},
+	})
+}
+
+func TestBundle(t *testing.T) {
+	shop := []string{"shop/catalog.proto", "shop/orders.proto"}
+	testGolden(t, []goldenTest{
+		{name: "bundle_file", fixture: "bundle", params: "bundle=file", generate: shop},
+		{name: "bundle_package", fixture: "bundle", params: "bundle=package", generate: shop},
+		{name: "bundle_package_2020_12", fixture: "bundle", params: "bundle=package,draft=2020-12", generate: shop},
+		{name: "bundle_file_collision", fixture: "bundle", params: "bundle=file", generate: []string{"shop/catalog.proto", "warehouse/catalog.proto"}, err: "shop/catalog.proto and warehouse/catalog.proto are both bundled into catalog.json"},
+		{name: "bundle_definition_collision", fixture: "bundle", params: "bundle=file", generate: []string{"warehouse/stock.proto"}, err: `messages warehouse.stock.Inbound.Item and warehouse.stock.Outbound.Item both map to definition "Item"`},
+		{name: "bundle_unsupported", fixture: "bundle", params: "bundle=service", generate: shop, err: `unsupported bundle "service"`},
+		{name: "bundle_openapi", fixture: "bundle", params: "bundle=file,output=openapi31", generate: shop, err: `bundle "file" can't be combined with output "openapi31"`},
+	})
+}
+
+func TestPaths(t *testing.T) {
+	generate := []string{"shop/catalog.proto", "warehouse/catalog.proto"}
+	testGolden(t, []goldenTest{
+		{name: "paths_source_relative", fixture: "bundle", params: "paths=source_relative", generate: generate},
+		{name: "paths_package", fixture: "bundle", params: "paths=package", generate: []string{"shop/orders.proto", "warehouse/stock.proto"}},
+		{name: "paths_file_template", fixture: "bundle", params: "paths=source_relative,file_template={name}.schema.json", generate: generate},
+		{name: "paths_source_relative_bundle", fixture: "bundle", params: "paths=source_relative,bundle=file", generate: generate},
+		{name: "paths_unsupported", fixture: "bundle", params: "paths=nested", generate: generate, err: `unsupported paths "nested"`},
+		{name: "paths_file_template_without_name", fixture: "bundle", params: "file_template=schema.json", generate: generate, err: `file template "schema.json" must contain {name}`},
+	})
+}
+
+func TestPropertyOrder(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "property_order_declaration", fixture: "order"},
+		{name: "property_order_number", fixture: "order", params: "property_order=number"},
+		{name: "property_order_unsupported", fixture: "order", params: "property_order=name", err: `unsupported property order "name"`},
+	})
+}
+
+func TestServices(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "services", fixture: "services", params: "services=true", generate: []string{"chat.proto"}},
+		{name: "services_package", fixture: "services", params: "services=true,paths=package,draft=2020-12", generate: []string{"chat.proto"}},
+		{name: "services_openapi", fixture: "services", params: "services=true,output=openapi31", generate: []string{"chat.proto"}, err: `services can't be combined with output "openapi31"`},
+	})
+}
+
+func TestAsyncAPI(t *testing.T) {
+	testGolden(t, []goldenTest{
+		{name: "asyncapi", fixture: "services", params: "output=asyncapi", generate: []string{"chat.proto"}},
+		{name: "asyncapi_yaml", fixture: "services", params: "output=asyncapi,output_format=yaml", generate: []string{"chat.proto"}},
+		{name: "asyncapi_ignored_payload", fixture: "services", params: "output=asyncapi", generate: []string{"ignored_payload.proto"}, err: "message chat.ignored.Event is used by a streaming method but is ignored"},
+		{name: "asyncapi_bundle", fixture: "services", params: "output=asyncapi,bundle=file", generate: []string{"chat.proto"}, err: `bundle "file" can't be combined with output "asyncapi"`},
+	})
+}
+
+func TestIds(t *testing.T) {
+	generate := []string{"ids.proto", "ids_annotated.proto"}
+	testGolden(t, []goldenTest{
+		{name: "id_base", fixture: "ids", params: "id_base=https://schemas.example.com/,paths=package", generate: generate},
+		{name: "id_template", fixture: "ids", params: "id_base=https://schemas.example.com/,id_template={version}/{full_name}.json", generate: generate},
+		{name: "id_template_urn", fixture: "ids", params: "id_template=urn:{package}:{name},repeated_defs=false", generate: []string{"ids.proto"}},
+		{name: "id_base_without_slash", fixture: "ids", params: "id_base=https://schemas.example.com", generate: generate, err: `id base "https://schemas.example.com" must end with a slash`},
+		{name: "id_base_annotation_without_slash", fixture: "ids", generate: []string{"ids_without_slash.proto"}, err: `id base "https://billing.example.com/schemas" of file ids_without_slash.proto must end with a slash`},
+		{name: "id_template_unsupported", fixture: "ids", params: "id_template={service}.json", generate: generate, err: "unsupported placeholder {service}"},
+	})
+}
+
+func TestRepeatedDefs(t *testing.T) {
+	both := []string{"common/v1/money.proto", "billing/v1/invoice.proto"}
+	testGolden(t, []goldenTest{
+		{name: "repeated_defs_false", fixture: "repeated_defs", params: "repeated_defs=false,paths=source_relative", generate: both},
+		{name: "repeated_defs_false_id_base", fixture: "repeated_defs", params: "repeated_defs=false,paths=package,id_base=https://schemas.example.com/", generate: both},
+		{name: "repeated_defs_false_not_generated", fixture: "repeated_defs", params: "repeated_defs=false", generate: []string{"billing/v1/invoice.proto"}, err: "message billing.v1.Invoice references common.v1.Money, which has no generated schema"},
+		{name: "repeated_defs_false_ignored", fixture: "repeated_defs", params: "repeated_defs=false", generate: []string{"billing/v1/draft.proto"}, err: "message billing.v1.Draft references billing.v1.Internal, which is ignored"},
+	})
+}
+
+func TestIncludeImports(t *testing.T) {
+	order := []string{"store/v1/order.proto"}
+	testGolden(t, []goldenTest{
+		{name: "include_imports_none", fixture: "include_imports", params: "paths=source_relative", generate: order},
+		{name: "include_imports_referenced", fixture: "include_imports", params: "include_imports=referenced,paths=source_relative", generate: order},
+		{name: "include_imports_all", fixture: "include_imports", params: "include_imports=all,paths=source_relative", generate: order},
+		{name: "include_imports_all_repeated_defs_false", fixture: "include_imports", params: "include_imports=all,repeated_defs=false,paths=source_relative", generate: order},
+		{name: "include_imports_unsupported", fixture: "include_imports", params: "include_imports=direct", generate: order, err: `unsupported include_imports "direct"`},
+		{name: "include_imports_openapi", fixture: "include_imports", params: "include_imports=all,output=openapi31", generate: order, err: `include_imports "all" can't be combined with output "openapi31"`},
+	})
+}
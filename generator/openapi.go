@@ -0,0 +1,420 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+// Output modes supported by the output parameter
+const (
+	OutputJSONSchema = "jsonschema"
+	OutputOpenAPI31  = "openapi31"
+	OutputOpenAPI30  = "openapi30"
+	OutputSwagger20  = "swagger20"
+)
+
+var (
+	// pathVariablePattern matches the variables of a google.api.http path template, e.g. {name=projects/*}
+	pathVariablePattern = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+	// packageVersionPattern matches the version component of a proto package, e.g. v1beta1
+	packageVersionPattern = regexp.MustCompile(`^v\d+((alpha|beta)\d*)?$`)
+)
+
+type OpenAPIDocument struct {
+	OpenAPI     string                      `json:"openapi,omitempty"`
+	Swagger     string                      `json:"swagger,omitempty"`
+	Info        *OpenAPIInfo                `json:"info"`
+	Consumes    []string                    `json:"consumes,omitempty"`
+	Produces    []string                    `json:"produces,omitempty"`
+	Paths       map[string]*OpenAPIPathItem `json:"paths"`
+	Definitions map[string]*Schema          `json:"definitions,omitempty"`
+	Components  *OpenAPIComponents          `json:"components,omitempty"`
+	// owners maps component names to the full names of the messages generated on their own
+	owners map[string]string
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type OpenAPIPathItem struct {
+	Get    *OpenAPIOperation `json:"get,omitempty"`
+	Put    *OpenAPIOperation `json:"put,omitempty"`
+	Post   *OpenAPIOperation `json:"post,omitempty"`
+	Delete *OpenAPIOperation `json:"delete,omitempty"`
+	Patch  *OpenAPIOperation `json:"patch,omitempty"`
+	// Custom holds the operations of custom HTTP methods, keyed by lowercase method name
+	Custom map[string]*OpenAPIOperation `json:"-"`
+}
+
+// MarshalJSON serializes the path item and merges in its custom operations
+func (p *OpenAPIPathItem) MarshalJSON() ([]byte, error) {
+	type pathItem OpenAPIPathItem
+	custom := make(map[string]interface{}, len(p.Custom))
+	for method, operation := range p.Custom {
+		custom[method] = operation
+	}
+	return marshalWithExtra((*pathItem)(p), custom)
+}
+
+// setOperation sets the operation of the HTTP method unless the path item already has one, which it returns instead
+func (p *OpenAPIPathItem) setOperation(method string, operation *OpenAPIOperation) *OpenAPIOperation {
+	operations := map[string]**OpenAPIOperation{
+		"get":    &p.Get,
+		"put":    &p.Put,
+		"post":   &p.Post,
+		"delete": &p.Delete,
+		"patch":  &p.Patch,
+	}
+	if existing, ok := operations[method]; ok {
+		if *existing == nil {
+			*existing = operation
+		}
+		return *existing
+	}
+	if p.Custom == nil {
+		p.Custom = make(map[string]*OpenAPIOperation)
+	}
+	if _, ok := p.Custom[method]; !ok {
+		p.Custom[method] = operation
+	}
+	return p.Custom[method]
+}
+
+type OpenAPIOperation struct {
+	OperationId string                      `json:"operationId"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Deprecated  bool                        `json:"deprecated,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	// Type is only used by Swagger 2.0, which describes non-body parameters without a schema
+	Type   string          `json:"type,omitempty"`
+	Schema *SchemaProperty `json:"schema,omitempty"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Schema      *SchemaProperty              `json:"schema,omitempty"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *SchemaProperty `json:"schema"`
+}
+
+// NewOpenAPIDocument creates a new OpenAPIDocument struct for the given output mode
+func NewOpenAPIDocument(output, title, version string) *OpenAPIDocument {
+	document := &OpenAPIDocument{
+		Info:   &OpenAPIInfo{Title: title, Version: version},
+		Paths:  make(map[string]*OpenAPIPathItem),
+		owners: make(map[string]string),
+	}
+	switch output {
+	case OutputSwagger20:
+		document.Swagger = "2.0"
+		document.Consumes = []string{"application/json"}
+		document.Produces = []string{"application/json"}
+		document.Definitions = make(map[string]*Schema)
+	case OutputOpenAPI30:
+		document.OpenAPI = "3.0.3"
+		document.Components = &OpenAPIComponents{Schemas: make(map[string]*Schema)}
+	default:
+		document.OpenAPI = "3.1.0"
+		document.Components = &OpenAPIComponents{Schemas: make(map[string]*Schema)}
+	}
+	return document
+}
+
+// schemas returns the schemas of the document, which Swagger 2.0 stores in definitions
+func (d *OpenAPIDocument) schemas() map[string]*Schema {
+	if d.Components == nil {
+		return d.Definitions
+	}
+	return d.Components.Schemas
+}
+
+// Json serializes the document into JSON format
+func (d *OpenAPIDocument) Json() []byte {
+	bytes, err := json.MarshalIndent(d, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes[:]
+}
+
+// checkOutput checks that the configured output mode is supported
+func (g *JSONSchemaGenerator) checkOutput() error {
+	switch *g.cfg.Output {
+	case OutputJSONSchema, OutputOpenAPI31, OutputOpenAPI30, OutputSwagger20, OutputAsyncAPI:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output %q. Use %q, %q, %q, %q or %q", *g.cfg.Output, OutputJSONSchema, OutputOpenAPI31, OutputOpenAPI30, OutputSwagger20, OutputAsyncAPI)
+	}
+}
+
+// openAPI checks if schemas are generated as components of OpenAPI documents
+func (g *JSONSchemaGenerator) openAPI() bool {
+	return *g.cfg.Output == OutputOpenAPI31 || g.legacyOpenAPI()
+}
+
+// legacyOpenAPI checks if schemas are generated in the OpenAPI 3.0 or Swagger 2.0 dialect
+func (g *JSONSchemaGenerator) legacyOpenAPI() bool {
+	return *g.cfg.Output == OutputOpenAPI30 || *g.cfg.Output == OutputSwagger20
+}
+
+// swagger checks if schemas are generated in the Swagger 2.0 dialect
+func (g *JSONSchemaGenerator) swagger() bool {
+	return *g.cfg.Output == OutputSwagger20
+}
+
+// packageVersion returns the version component of the proto package, e.g. v1 for acme.library.v1
+func packageVersion(pkg string) string {
+	components := strings.Split(pkg, ".")
+	if version := components[len(components)-1]; packageVersionPattern.MatchString(version) {
+		return version
+	}
+	return ""
+}
+
+// buildOpenAPIDocuments builds one OpenAPI document per proto package from the messages and services of the generated files
+func (g *JSONSchemaGenerator) buildOpenAPIDocuments() error {
+	documents := make(map[string]*OpenAPIDocument)
+	packages := []string{}
+	for _, file := range g.plugin.Files {
+		if !file.Generate || g.getFileOptions(file.Desc).GetIgnore() {
+			continue
+		}
+		pkg := string(file.Desc.Package())
+		document, ok := documents[pkg]
+		if !ok {
+			title, version := pkg, packageVersion(pkg)
+			if title == "" {
+				title = file.Desc.Path()
+			}
+			if version == "" {
+				version = "0.0.0"
+			}
+			document = NewOpenAPIDocument(*g.cfg.Output, title, version)
+			documents[pkg] = document
+			packages = append(packages, pkg)
+		}
+		for _, message := range g.includedMessages(file) {
+			if err := g.addComponent(document, message); err != nil {
+				return err
+			}
+		}
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				if g.getMethodOptions(method).GetIgnore() {
+					continue
+				}
+				if err := g.addOperations(document, service, method); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, pkg := range packages {
+		schemas := documents[pkg].schemas()
+		names := make([]string, 0, len(schemas))
+		for name := range schemas {
+			names = append(names, name)
+		}
+		// schemas are rewritten in a stable order so that warnings are too
+		sort.Strings(names)
+		for _, name := range names {
+			if err := g.applyDraftToSchema(schemas[name]); err != nil {
+				return fmt.Errorf("invalid extra keywords for schema %s: %w", name, err)
+			}
+			g.warnUnvalidated("schema " + name)
+		}
+		filename := "openapi"
+		if g.swagger() {
+			filename = "swagger"
+		}
+		if pkg != "" {
+			filename = strings.ReplaceAll(pkg, ".", "/") + "/" + filename
+		}
+		if err := g.writeFile(filename+g.fileExtension(), documents[pkg].Json()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addComponent adds the schema of the message and of the messages it references to the components of the document
+func (g *JSONSchemaGenerator) addComponent(document *OpenAPIDocument, message *protogen.Message) error {
+	_, err := g.addDefinition(document.schemas(), document.owners, message)
+	return err
+}
+
+// componentRef adds the component of the message to the document and returns a reference to it
+func (g *JSONSchemaGenerator) componentRef(document *OpenAPIDocument, message *protogen.Message) (*SchemaProperty, error) {
+	added, err := g.addDefinition(document.schemas(), document.owners, message)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, fmt.Errorf("message %s is used by an HTTP body but is ignored", message.Desc.FullName())
+	}
+	return &SchemaProperty{Ref: g.definitionsRef(g.definitionName(message))}, nil
+}
+
+// addOperations adds the operations of the method to the paths of the document
+func (g *JSONSchemaGenerator) addOperations(document *OpenAPIDocument, service *protogen.Service, method *protogen.Method) error {
+	for _, message := range []*protogen.Message{method.Input, method.Output} {
+		if err := g.addComponent(document, message); err != nil {
+			return err
+		}
+	}
+	rule, ok := proto.GetExtension(method.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		// gRPC transcoding conventions: every method without an HTTP rule is a POST with the request as body
+		rule = &annotations.HttpRule{
+			Pattern: &annotations.HttpRule_Post{Post: fmt.Sprintf("/%s/%s", service.Desc.FullName(), method.Desc.Name())},
+			Body:    "*",
+		}
+	}
+	bindings := append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+	for i, binding := range bindings {
+		operationId := fmt.Sprintf("%s_%s", service.Desc.Name(), method.Desc.Name())
+		if i > 0 {
+			operationId = fmt.Sprintf("%s%d", operationId, i+1)
+		}
+		if err := g.addOperation(document, service, method, binding, operationId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addOperation adds the operation of a single HTTP binding of the method to the paths of the document
+func (g *JSONSchemaGenerator) addOperation(document *OpenAPIDocument, service *protogen.Service, method *protogen.Method, rule *annotations.HttpRule, operationId string) error {
+	var httpMethod, template string
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		httpMethod, template = "get", pattern.Get
+	case *annotations.HttpRule_Put:
+		httpMethod, template = "put", pattern.Put
+	case *annotations.HttpRule_Post:
+		httpMethod, template = "post", pattern.Post
+	case *annotations.HttpRule_Delete:
+		httpMethod, template = "delete", pattern.Delete
+	case *annotations.HttpRule_Patch:
+		httpMethod, template = "patch", pattern.Patch
+	case *annotations.HttpRule_Custom:
+		httpMethod, template = strings.ToLower(pattern.Custom.GetKind()), pattern.Custom.GetPath()
+	default:
+		return fmt.Errorf("method %s has an HTTP rule without a pattern", method.Desc.FullName())
+	}
+	operation := &OpenAPIOperation{
+		OperationId: operationId,
+		Description: g.reformatComment(method.Comments.Leading),
+		Tags:        []string{string(service.Desc.Name())},
+		Deprecated:  g.isDeprecated(method.Desc),
+		Responses:   make(map[string]*OpenAPIResponse),
+	}
+	for _, variable := range pathVariablePattern.FindAllStringSubmatch(template, -1) {
+		parameter := &OpenAPIParameter{Name: variable[1], In: "path", Required: true}
+		if g.swagger() {
+			parameter.Type = "string"
+		} else {
+			parameter.Schema = &SchemaProperty{Type: "string"}
+		}
+		operation.Parameters = append(operation.Parameters, parameter)
+	}
+	path := pathVariablePattern.ReplaceAllString(template, "{$1}")
+	if body := rule.GetBody(); body != "" {
+		schema, err := g.bodySchema(document, method.Input, body)
+		if err != nil {
+			return err
+		}
+		if g.swagger() {
+			operation.Parameters = append(operation.Parameters, &OpenAPIParameter{Name: "body", In: "body", Required: true, Schema: schema})
+		} else {
+			operation.RequestBody = &OpenAPIRequestBody{
+				Required: true,
+				Content:  map[string]*OpenAPIMediaType{"application/json": {Schema: schema}},
+			}
+		}
+	}
+	responseBody := rule.GetResponseBody()
+	if responseBody == "" {
+		responseBody = "*"
+	}
+	schema, err := g.bodySchema(document, method.Output, responseBody)
+	if err != nil {
+		return err
+	}
+	if g.swagger() {
+		operation.Responses["200"] = &OpenAPIResponse{Description: "OK", Schema: schema}
+	} else {
+		operation.Responses["200"] = &OpenAPIResponse{
+			Description: "OK",
+			Content:     map[string]*OpenAPIMediaType{"application/json": {Schema: schema}},
+		}
+	}
+	pathItem, ok := document.Paths[path]
+	if !ok {
+		pathItem = &OpenAPIPathItem{}
+		document.Paths[path] = pathItem
+	}
+	if existing := pathItem.setOperation(httpMethod, operation); existing != operation {
+		return fmt.Errorf("operations %s and %s both map to %s %s", existing.OperationId, operationId, strings.ToUpper(httpMethod), path)
+	}
+	return nil
+}
+
+// bodySchema returns the schema of an HTTP body, which is either the whole message or one of its fields
+func (g *JSONSchemaGenerator) bodySchema(document *OpenAPIDocument, message *protogen.Message, body string) (*SchemaProperty, error) {
+	if body == "*" {
+		return g.componentRef(document, message)
+	}
+	field := g.lookupField(message, body)
+	if field == nil {
+		return nil, fmt.Errorf("message %s has no field named %q", message.Desc.FullName(), body)
+	}
+	schema, err := g.parseField(field)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		schema = &SchemaProperty{}
+	}
+	// the body field references the component of its message, which must therefore be generated
+	if schema.IsRef {
+		if _, err := g.componentRef(document, field.Message); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.applyDraftToProperty(schema); err != nil {
+		return nil, fmt.Errorf("invalid extra keywords for field %s: %w", field.Desc.FullName(), err)
+	}
+	g.warnUnvalidated("the HTTP body " + string(field.Desc.FullName()))
+	return schema, nil
+}
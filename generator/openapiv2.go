@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"encoding/json"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+// applyOpenAPIv2Field translates the grpc-gateway openapiv2_field annotation of the field into keywords of the SchemaProperty
+func (g *JSONSchemaGenerator) applyOpenAPIv2Field(field *protogen.Field, propertySchema *SchemaProperty) {
+	jsonSchema, ok := proto.GetExtension(field.Desc.Options(), options.E_Openapiv2Field).(*options.JSONSchema)
+	if !ok || jsonSchema == nil {
+		return
+	}
+	// field annotations list the field itself as required
+	if len(jsonSchema.GetRequired()) > 0 {
+		propertySchema.IsRequired = true
+	}
+	keywords := g.openAPIv2Keywords(jsonSchema)
+	if defaultValue := jsonSchema.GetDefault(); defaultValue != "" {
+		keywords.Default = g.jsonValue(defaultValue)
+	}
+	if example := jsonSchema.GetExample(); example != "" {
+		keywords.Example = g.jsonValue(example)
+	}
+	g.applyOpenAPIProperty(keywords, propertySchema)
+}
+
+// applyOpenAPIv2Schema translates the grpc-gateway openapiv2_schema annotation of the message into keywords of the Schema
+func (g *JSONSchemaGenerator) applyOpenAPIv2Schema(message *protogen.Message, schema *Schema) error {
+	messageSchema, ok := proto.GetExtension(message.Desc.Options(), options.E_Openapiv2Schema).(*options.Schema)
+	if !ok || messageSchema == nil {
+		return nil
+	}
+	keywords := g.openAPIv2Keywords(messageSchema.GetJsonSchema())
+	if example := messageSchema.GetExample(); example != "" {
+		keywords.Example = g.jsonValue(example)
+	}
+	return g.applyOpenAPISchema(keywords, message, schema)
+}
+
+// openAPIv2Keywords reads the keywords of the openapiv2 JSONSchema
+func (g *JSONSchemaGenerator) openAPIv2Keywords(jsonSchema *options.JSONSchema) *openAPIKeywords {
+	keywords := &openAPIKeywords{
+		Title:            jsonSchema.GetTitle(),
+		Description:      jsonSchema.GetDescription(),
+		ReadOnly:         jsonSchema.GetReadOnly(),
+		MultipleOf:       jsonSchema.GetMultipleOf(),
+		Maximum:          jsonSchema.GetMaximum(),
+		ExclusiveMaximum: jsonSchema.GetExclusiveMaximum(),
+		Minimum:          jsonSchema.GetMinimum(),
+		ExclusiveMinimum: jsonSchema.GetExclusiveMinimum(),
+		MaxLength:        int64(jsonSchema.GetMaxLength()),
+		MinLength:        int64(jsonSchema.GetMinLength()),
+		Pattern:          jsonSchema.GetPattern(),
+		MaxItems:         int64(jsonSchema.GetMaxItems()),
+		MinItems:         int64(jsonSchema.GetMinItems()),
+		UniqueItems:      jsonSchema.GetUniqueItems(),
+		MaxProperties:    int64(jsonSchema.GetMaxProperties()),
+		MinProperties:    int64(jsonSchema.GetMinProperties()),
+		Required:         jsonSchema.GetRequired(),
+		Format:           jsonSchema.GetFormat(),
+		Extensions:       make(map[string]interface{}),
+	}
+	for _, value := range jsonSchema.GetEnum() {
+		keywords.Enum = append(keywords.Enum, value)
+	}
+	for name, value := range jsonSchema.GetExtensions() {
+		keywords.Extensions[name] = value.AsInterface()
+	}
+	return keywords
+}
+
+// jsonValue parses the given JSON text, falling back to the raw text if it isn't valid JSON
+func (g *JSONSchemaGenerator) jsonValue(text string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return text
+	}
+	return value
+}
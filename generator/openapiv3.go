@@ -0,0 +1,83 @@
+package generator
+
+import (
+	openapiv3 "github.com/google/gnostic-models/openapiv3"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// applyOpenAPIv3Property translates the gnostic openapi.v3.property annotation of the field into keywords of the SchemaProperty
+func (g *JSONSchemaGenerator) applyOpenAPIv3Property(field *protogen.Field, propertySchema *SchemaProperty) {
+	property, ok := proto.GetExtension(field.Desc.Options(), openapiv3.E_Property).(*openapiv3.Schema)
+	if !ok || property == nil {
+		return
+	}
+	g.applyOpenAPIProperty(g.openAPIv3Keywords(property), propertySchema)
+}
+
+// applyOpenAPIv3Schema translates the gnostic openapi.v3.schema annotation of the message into keywords of the Schema
+func (g *JSONSchemaGenerator) applyOpenAPIv3Schema(message *protogen.Message, schema *Schema) error {
+	messageSchema, ok := proto.GetExtension(message.Desc.Options(), openapiv3.E_Schema).(*openapiv3.Schema)
+	if !ok || messageSchema == nil {
+		return nil
+	}
+	return g.applyOpenAPISchema(g.openAPIv3Keywords(messageSchema), message, schema)
+}
+
+// openAPIv3Keywords reads the keywords of the gnostic Schema
+func (g *JSONSchemaGenerator) openAPIv3Keywords(schema *openapiv3.Schema) *openAPIKeywords {
+	keywords := &openAPIKeywords{
+		Title:            schema.GetTitle(),
+		Description:      schema.GetDescription(),
+		ReadOnly:         schema.GetReadOnly(),
+		WriteOnly:        schema.GetWriteOnly(),
+		Deprecated:       schema.GetDeprecated(),
+		Nullable:         schema.GetNullable(),
+		MultipleOf:       schema.GetMultipleOf(),
+		Maximum:          schema.GetMaximum(),
+		ExclusiveMaximum: schema.GetExclusiveMaximum(),
+		Minimum:          schema.GetMinimum(),
+		ExclusiveMinimum: schema.GetExclusiveMinimum(),
+		MaxLength:        schema.GetMaxLength(),
+		MinLength:        schema.GetMinLength(),
+		Pattern:          schema.GetPattern(),
+		MaxItems:         schema.GetMaxItems(),
+		MinItems:         schema.GetMinItems(),
+		UniqueItems:      schema.GetUniqueItems(),
+		MaxProperties:    schema.GetMaxProperties(),
+		MinProperties:    schema.GetMinProperties(),
+		Required:         schema.GetRequired(),
+		Format:           schema.GetFormat(),
+		Extensions:       make(map[string]interface{}),
+	}
+	if defaultValue := schema.GetDefault(); defaultValue != nil {
+		switch value := defaultValue.GetOneof().(type) {
+		case *openapiv3.DefaultType_Number:
+			keywords.Default = value.Number
+		case *openapiv3.DefaultType_Boolean:
+			keywords.Default = value.Boolean
+		case *openapiv3.DefaultType_String_:
+			keywords.Default = value.String_
+		}
+	}
+	if example := schema.GetExample(); example != nil {
+		keywords.Example = g.openAPIv3Value(example)
+	}
+	for _, value := range schema.GetEnum() {
+		keywords.Enum = append(keywords.Enum, g.openAPIv3Value(value))
+	}
+	for _, extension := range schema.GetSpecificationExtension() {
+		keywords.Extensions[extension.GetName()] = g.openAPIv3Value(extension.GetValue())
+	}
+	return keywords
+}
+
+// openAPIv3Value parses the YAML text of the gnostic Any, falling back to the raw text if it isn't valid YAML
+func (g *JSONSchemaGenerator) openAPIv3Value(value *openapiv3.Any) interface{} {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(value.GetYaml()), &parsed); err != nil {
+		return value.GetYaml()
+	}
+	return parsed
+}
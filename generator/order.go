@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Orders supported by the property_order parameter
+const (
+	OrderDeclaration = "declaration"
+	OrderNumber      = "number"
+)
+
+// checkPropertyOrder checks that the configured property order is supported
+func (g *JSONSchemaGenerator) checkPropertyOrder() error {
+	switch *g.cfg.PropertyOrder {
+	case OrderDeclaration, OrderNumber:
+		return nil
+	default:
+		return fmt.Errorf("unsupported property order %q. Use %q or %q", *g.cfg.PropertyOrder, OrderDeclaration, OrderNumber)
+	}
+}
+
+// orderedFields returns the fields of the message in the order of the generated properties
+func (g *JSONSchemaGenerator) orderedFields(message *protogen.Message) []*protogen.Field {
+	fields := append([]*protogen.Field{}, message.Fields...)
+	if *g.cfg.PropertyOrder == OrderNumber {
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].Desc.Number() < fields[j].Desc.Number()
+		})
+	}
+	return fields
+}
+
+// orderRequired removes duplicates from the required properties and puts them in the order of the generated properties.
+// Names which aren't fields of the message are kept after them
+func (g *JSONSchemaGenerator) orderRequired(message *protogen.Message, required []string) []string {
+	if len(required) == 0 {
+		return required
+	}
+	names := make(map[string]bool, len(required))
+	for _, name := range required {
+		names[name] = true
+	}
+	ordered := []string{}
+	for _, field := range g.orderedFields(message) {
+		if name := field.Desc.JSONName(); names[name] {
+			ordered = append(ordered, name)
+			delete(names, name)
+		}
+	}
+	for _, name := range required {
+		if names[name] {
+			ordered = append(ordered, name)
+			delete(names, name)
+		}
+	}
+	return ordered
+}
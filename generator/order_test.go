@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOrderRequired(t *testing.T) {
+	message := lookupTestMessage(t, "order", "order.Ticket")
+	tests := []struct {
+		name     string
+		params   string
+		required []string
+		expected []string
+	}{
+		{name: "empty", required: []string{}, expected: []string{}},
+		{name: "declaration order", required: []string{"priority", "id", "title"}, expected: []string{"title", "id", "priority"}},
+		{name: "number order", params: "property_order=number", required: []string{"title", "priority", "id"}, expected: []string{"id", "priority", "title"}},
+		{name: "duplicates", required: []string{"id", "title", "id"}, expected: []string{"title", "id"}},
+		{name: "unknown names last", required: []string{"extra", "id", "other", "extra"}, expected: []string{"id", "extra", "other"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			required := newTestGenerator(t, test.params).orderRequired(message, test.required)
+			if strings.Join(required, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("expected %v, got %v", test.expected, required)
+			}
+		})
+	}
+}
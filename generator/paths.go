@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Layouts supported by the paths parameter
+const (
+	PathsFlat           = "flat"
+	PathsSourceRelative = "source_relative"
+	PathsPackage        = "package"
+)
+
+// nameVariable is replaced by the schema name in the file template
+const nameVariable = "{name}"
+
+// checkPaths checks that the configured layout and file template are supported
+func (g *JSONSchemaGenerator) checkPaths() error {
+	switch *g.cfg.Paths {
+	case PathsFlat, PathsSourceRelative, PathsPackage:
+	default:
+		return fmt.Errorf("unsupported paths %q. Use %q, %q or %q", *g.cfg.Paths, PathsFlat, PathsSourceRelative, PathsPackage)
+	}
+	if template := *g.cfg.FileTemplate; template != "" && !strings.Contains(template, nameVariable) {
+		return fmt.Errorf("file template %q must contain %s", template, nameVariable)
+	}
+	return nil
+}
+
+// outputDir returns the directory of the schemas generated from the given proto file
+func (g *JSONSchemaGenerator) outputDir(file protoreflect.FileDescriptor) string {
+	switch *g.cfg.Paths {
+	case PathsSourceRelative:
+		if dir := path.Dir(file.Path()); dir != "." {
+			return dir
+		}
+	case PathsPackage:
+		return strings.ReplaceAll(string(file.Package()), ".", "/")
+	}
+	return ""
+}
+
+// outputName returns the file name of the schema with the given name
+func (g *JSONSchemaGenerator) outputName(name string) string {
+	template := *g.cfg.FileTemplate
+	if template == "" {
+		template = nameVariable + g.fileExtension()
+	}
+	return strings.ReplaceAll(template, nameVariable, name)
+}
+
+// outputPath returns the path of the schema with the given name, generated from the given proto file
+func (g *JSONSchemaGenerator) outputPath(file protoreflect.FileDescriptor, name string) string {
+	return path.Join(g.outputDir(file), g.outputName(name))
+}
+
+// relativeRef returns the reference from the schema at the given path to the schema at the target path
+func relativeRef(from, target string) string {
+	fromDirs := strings.Split(path.Dir(from), "/")
+	targetDirs := strings.Split(path.Dir(target), "/")
+	if fromDirs[0] == "." {
+		fromDirs = nil
+	}
+	if targetDirs[0] == "." {
+		targetDirs = nil
+	}
+	common := 0
+	for common < len(fromDirs) && common < len(targetDirs) && fromDirs[common] == targetDirs[common] {
+		common++
+	}
+	parts := []string{}
+	for range fromDirs[common:] {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, targetDirs[common:]...)
+	return path.Join(append(parts, path.Base(target))...)
+}
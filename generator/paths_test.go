@@ -0,0 +1,27 @@
+package generator
+
+import "testing"
+
+func TestRelativeRef(t *testing.T) {
+	tests := []struct {
+		from     string
+		target   string
+		expected string
+	}{
+		{from: "a.json", target: "b.json", expected: "b.json"},
+		{from: "shop/a.json", target: "shop/b.json", expected: "b.json"},
+		{from: "a.json", target: "shop/b.json", expected: "shop/b.json"},
+		{from: "shop/a.json", target: "b.json", expected: "../b.json"},
+		{from: "shop/v1/a.json", target: "shop/v2/b.json", expected: "../v2/b.json"},
+		{from: "shop/v1/a.json", target: "warehouse/b.json", expected: "../../warehouse/b.json"},
+		{from: "shop/a.json", target: "shop/v1/b.json", expected: "v1/b.json"},
+		{from: "shop/a.json", target: "shopping/b.json", expected: "../shopping/b.json"},
+	}
+	for _, test := range tests {
+		t.Run(test.from+" to "+test.target, func(t *testing.T) {
+			if ref := relativeRef(test.from, test.target); ref != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, ref)
+			}
+		})
+	}
+}
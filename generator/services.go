@@ -0,0 +1,155 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	protoc_gen_jsonschema "github.com/TheRebelOfBabylon/protoc-gen-jsonschema"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+// Streaming kinds of methods listed in service indexes
+const (
+	StreamingUnary  = "unary"
+	StreamingClient = "client_streaming"
+	StreamingServer = "server_streaming"
+	StreamingBidi   = "bidi_streaming"
+)
+
+type ServiceIndex struct {
+	Service     string         `json:"service"`
+	Description string         `json:"description,omitempty"`
+	Deprecated  bool           `json:"deprecated,omitempty"`
+	Methods     []*MethodIndex `json:"methods"`
+}
+
+type MethodIndex struct {
+	Name       string `json:"name"`
+	Streaming  string `json:"streaming"`
+	Input      string `json:"input"`
+	Output     string `json:"output"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+	Schema     string `json:"schema"`
+}
+
+// Json serializes the index into JSON format
+func (i *ServiceIndex) Json() []byte {
+	bytes, err := json.MarshalIndent(i, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+	return bytes[:]
+}
+
+// checkServices checks that method schemas can be generated with the configured output
+func (g *JSONSchemaGenerator) checkServices() error {
+	if *g.cfg.Services && (g.openAPI() || g.asyncAPI()) {
+		return fmt.Errorf("services can't be combined with output %q, which generates paths from services", *g.cfg.Output)
+	}
+	return nil
+}
+
+// getMethodOptions returns the custom annotations of the method
+func (g *JSONSchemaGenerator) getMethodOptions(method *protogen.Method) *protoc_gen_jsonschema.MethodOptions {
+	if opt := proto.GetExtension(method.Desc.Options(), protoc_gen_jsonschema.E_MethodOptions); opt != nil {
+		if methodOpts, ok := opt.(*protoc_gen_jsonschema.MethodOptions); ok {
+			return methodOpts
+		}
+	}
+	return nil
+}
+
+// streamingKind returns whether the requests, the responses or both of the method are streamed
+func streamingKind(method *protogen.Method) string {
+	switch {
+	case method.Desc.IsStreamingClient() && method.Desc.IsStreamingServer():
+		return StreamingBidi
+	case method.Desc.IsStreamingClient():
+		return StreamingClient
+	case method.Desc.IsStreamingServer():
+		return StreamingServer
+	default:
+		return StreamingUnary
+	}
+}
+
+// methodName returns the name of the schema generated for the method
+func methodName(service *protogen.Service, method *protogen.Method) string {
+	return fmt.Sprintf("%s.%s", service.Desc.Name(), method.Desc.Name())
+}
+
+// indexPath returns the path of the index generated for the service
+func (g *JSONSchemaGenerator) indexPath(file *protogen.File, service *protogen.Service) string {
+	return g.outputPath(file.Desc, string(service.Desc.Name()))
+}
+
+// buildSchemasFromServices builds one schema per method, with the request and response messages as properties,
+// and one index per service listing its methods
+func (g *JSONSchemaGenerator) buildSchemasFromServices(file *protogen.File) error {
+	// method schemas are self-contained, so they always carry the definitions of the messages they use
+	g.selfContained = true
+	defer func() { g.selfContained = false }()
+	fileOpts := g.getFileOptions(file.Desc)
+	for _, service := range file.Services {
+		indexPath := g.indexPath(file, service)
+		index := &ServiceIndex{
+			Service:     string(service.Desc.FullName()),
+			Description: g.reformatComment(service.Comments.Leading),
+			Deprecated:  g.isDeprecated(service.Desc),
+			Methods:     []*MethodIndex{},
+		}
+		for _, method := range service.Methods {
+			methodOpts := g.getMethodOptions(method)
+			if methodOpts.GetIgnore() {
+				continue
+			}
+			name := methodName(service, method)
+			methodPath := g.outputPath(file.Desc, name)
+			schema := NewSchema(
+				g.schemaId(file.Desc, name, string(method.Desc.FullName()), methodPath),
+				fileOpts.GetTitlePrefix()+name,
+				g.reformatComment(method.Comments.Leading),
+				"object",
+			)
+			if id := methodOpts.GetId(); id != "" {
+				schema.Id = id
+			}
+			if g.isDeprecated(method.Desc) {
+				schema.Deprecated = true
+				schema.Description = g.deprecatedDescription(schema.Description)
+			}
+			owners := make(map[string]string)
+			for _, property := range []struct {
+				name    string
+				message *protogen.Message
+			}{{"request", method.Input}, {"response", method.Output}} {
+				added, err := g.addDefinition(schema.Definitions, owners, property.message)
+				if err != nil {
+					return err
+				}
+				if added {
+					schema.Properties.Set(property.name, &SchemaProperty{Ref: g.definitionsRef(g.definitionName(property.message))})
+				}
+			}
+			if err := g.applyDraft(schema); err != nil {
+				return fmt.Errorf("invalid extra keywords for method %s: %w", method.Desc.FullName(), err)
+			}
+			if err := g.writeFile(methodPath, schema.Json()); err != nil {
+				return err
+			}
+			index.Methods = append(index.Methods, &MethodIndex{
+				Name:       string(method.Desc.Name()),
+				Streaming:  streamingKind(method),
+				Input:      string(method.Input.Desc.FullName()),
+				Output:     string(method.Output.Desc.FullName()),
+				Deprecated: g.isDeprecated(method.Desc),
+				Schema:     relativeRef(indexPath, methodPath),
+			})
+		}
+		if err := g.writeFile(indexPath, index.Json()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,37 +2,175 @@ package generator
 
 import (
 	"encoding/json"
+	"sort"
 )
 
 type SchemaProperty struct {
-	Type 		string 					   `json:"type,omitempty"`
+	// Type is either a string or a list of strings
+	Type 		interface{}				   `json:"type,omitempty"`
 	Format      string					   `json:"format,omitempty"`
+	Title		string					   `json:"title,omitempty"`
 	Description string 					   `json:"description,omitempty"`
+	Default		interface{}				   `json:"default,omitempty"`
+	Examples	[]interface{}			   `json:"examples,omitempty"`
 	Ref		    string 					   `json:"$ref,omitempty"`
-	Enum		[]string 				   `json:"enum,omitempty"`
-	Properties  map[string]*SchemaProperty `json:"properties,omitempty"`
+	Enum		[]interface{} 			   `json:"enum,omitempty"`
+	Properties  Properties				   `json:"properties,omitempty"`
 	Required    []string				   `json:"required,omitempty"`
 	Items		*SchemaProperty			   `json:"items,omitempty"`
 	MinItems    int32					   `json:"minItems,omitempty"`
+	MaxItems    int32					   `json:"maxItems,omitempty"`
+	UniqueItems bool					   `json:"uniqueItems,omitempty"`
 	MinLength   int32					   `json:"minLength,omitempty"`
 	MaxLength   int32					   `json:"maxLength,omitempty"`
 	Pattern     string					   `json:"pattern,omitempty"`
+	MultipleOf	float64					   `json:"multipleOf,omitempty"`
+	Minimum		interface{}				   `json:"minimum,omitempty"`
+	Maximum		interface{}				   `json:"maximum,omitempty"`
+	ExclusiveMinimum interface{}		   `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum interface{}		   `json:"exclusiveMaximum,omitempty"`
+	MinProperties int32					   `json:"minProperties,omitempty"`
+	MaxProperties int32					   `json:"maxProperties,omitempty"`
+	PropertyNames *SchemaProperty		   `json:"propertyNames,omitempty"`
+	// AdditionalProperties is either a bool or a *SchemaProperty
+	AdditionalProperties interface{}	   `json:"additionalProperties,omitempty"`
+	Const		interface{}				   `json:"const,omitempty"`
+	ReadOnly	bool					   `json:"readOnly,omitempty"`
+	WriteOnly	bool					   `json:"writeOnly,omitempty"`
+	Deprecated	bool					   `json:"deprecated,omitempty"`
+	If			*SchemaProperty			   `json:"if,omitempty"`
+	Then		*SchemaProperty			   `json:"then,omitempty"`
+	Else		*SchemaProperty			   `json:"else,omitempty"`
+	AllOf		[]*SchemaProperty		   `json:"allOf,omitempty"`
+	AnyOf		[]*SchemaProperty		   `json:"anyOf,omitempty"`
+	Not			*SchemaProperty			   `json:"not,omitempty"`
+	Extra		map[string]interface{}	   `json:"-"`
 	IsRequired  bool					   `json:"-"`
 	IsRef		bool                       `json:"-"`
+	// Nullable is written in the way of the configured draft or OpenAPI dialect
+	Nullable	bool					   `json:"-"`
+}
+
+// MarshalJSON serializes the property and merges in its extra keywords
+func (p *SchemaProperty) MarshalJSON() ([]byte, error) {
+	type schemaProperty SchemaProperty
+	return marshalWithExtra((*schemaProperty)(p), p.Extra)
 }
 
 type Schema struct {
 	Id 		  	string 					   `json:"$id,omitempty"`
+	LegacyId	string					   `json:"id,omitempty"`
 	SchemaRef 	string  				   `json:"$schema,omitempty"`
 	Title	  	string 					   `json:"title,omitempty"`
 	Description string 					   `json:"description,omitempty"`
+	Examples	[]interface{}			   `json:"examples,omitempty"`
 	Type		string 					   `json:"type,omitempty"`
-	Properties  map[string]*SchemaProperty `json:"properties,omitempty"`
+	Deprecated	bool					   `json:"deprecated,omitempty"`
+	Properties  Properties				   `json:"properties,omitempty"`
 	Required    []string				   `json:"required,omitempty"`
+	// AdditionalProperties is either a bool or a *SchemaProperty
+	AdditionalProperties interface{}	   `json:"additionalProperties,omitempty"`
+	PatternProperties map[string]*SchemaProperty `json:"patternProperties,omitempty"`
+	MinProperties int32					   `json:"minProperties,omitempty"`
+	MaxProperties int32					   `json:"maxProperties,omitempty"`
+	If			*SchemaProperty			   `json:"if,omitempty"`
+	Then		*SchemaProperty			   `json:"then,omitempty"`
+	Else		*SchemaProperty			   `json:"else,omitempty"`
+	AllOf		[]*SchemaProperty		   `json:"allOf,omitempty"`
+	AnyOf		[]*SchemaProperty		   `json:"anyOf,omitempty"`
+	Dependencies map[string][]string	   `json:"dependencies,omitempty"`
+	DependentRequired map[string][]string  `json:"dependentRequired,omitempty"`
 	Definitions map[string]*Schema		   `json:"definitions,omitempty"`
+	Defs		map[string]*Schema		   `json:"$defs,omitempty"`
+	Extra		map[string]interface{}	   `json:"-"`
 	IsRequired  bool					   `json:"-"`
 }
 
+// MarshalJSON serializes the schema and merges in its extra keywords
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	return marshalWithExtra((*schema)(s), s.Extra)
+}
+
+// marshalWithExtra serializes v and appends the extra keywords after the generated ones, in sorted order
+func marshalWithExtra(v interface{}, extra map[string]interface{}) ([]byte, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return bytes, err
+	}
+	keywords := make([]string, 0, len(extra))
+	for keyword := range extra {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	buf := bytes[:len(bytes)-1]
+	for i, keyword := range keywords {
+		key, err := json.Marshal(keyword)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(extra[keyword])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 || len(buf) > 1 {
+			buf = append(buf, ',')
+		}
+		buf = append(append(append(buf, key...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
+
+// Properties keeps the properties of a schema in the order in which they are set
+type Properties []*NamedProperty
+
+// NamedProperty is a property of a schema with its name
+type NamedProperty struct {
+	Name     string
+	Property *SchemaProperty
+}
+
+// Set sets the property with the given name, keeping its position if it was already set
+func (p *Properties) Set(name string, property *SchemaProperty) {
+	for _, namedProperty := range *p {
+		if namedProperty.Name == name {
+			namedProperty.Property = property
+			return
+		}
+	}
+	*p = append(*p, &NamedProperty{Name: name, Property: property})
+}
+
+// Get returns the property with the given name, or nil if it isn't set
+func (p Properties) Get(name string) *SchemaProperty {
+	for _, namedProperty := range p {
+		if namedProperty.Name == name {
+			return namedProperty.Property
+		}
+	}
+	return nil
+}
+
+// MarshalJSON serializes the properties into a JSON object, keeping their order
+func (p Properties) MarshalJSON() ([]byte, error) {
+	buf := []byte{'{'}
+	for i, namedProperty := range p {
+		key, err := json.Marshal(namedProperty.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(namedProperty.Property)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(append(append(buf, key...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
+
 // NewSchema creates a NewSchema struct
 func NewSchema(id, title, description, schemaType string) *Schema {
 	return &Schema{
@@ -41,7 +179,6 @@ func NewSchema(id, title, description, schemaType string) *Schema {
 		Title: title,
 		Description: description,
 		Type: schemaType,
-		Properties: make(map[string]*SchemaProperty),
 		Definitions: make(map[string]*Schema),
 	}
 }
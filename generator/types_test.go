@@ -0,0 +1,65 @@
+package generator
+
+import "testing"
+
+func TestMarshalWithExtra(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		extra    map[string]interface{}
+		expected string
+	}{
+		{name: "no extra", value: &SchemaProperty{Type: "string"}, expected: `{"type":"string"}`},
+		{name: "empty value", value: struct{}{}, extra: map[string]interface{}{"x-a": 1}, expected: `{"x-a":1}`},
+		{name: "sorted keywords", value: &SchemaProperty{Type: "string"}, extra: map[string]interface{}{"x-b": true, "x-a": "a"}, expected: `{"type":"string","x-a":"a","x-b":true}`},
+		{name: "nested values", value: &SchemaProperty{Type: "array"}, extra: map[string]interface{}{"contains": map[string]interface{}{"const": "a"}}, expected: `{"type":"array","contains":{"const":"a"}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytes, err := marshalWithExtra(test.value, test.extra)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(bytes) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, bytes)
+			}
+		})
+	}
+}
+
+func TestPropertiesMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties Properties
+		expected   string
+	}{
+		{name: "empty", properties: Properties{}, expected: `{}`},
+		{name: "insertion order", properties: Properties{{Name: "b", Property: &SchemaProperty{Type: "string"}}, {Name: "a", Property: &SchemaProperty{Type: "integer"}}}, expected: `{"b":{"type":"string"},"a":{"type":"integer"}}`},
+		{name: "escaped names", properties: Properties{{Name: `a"b`, Property: &SchemaProperty{}}}, expected: `{"a\"b":{}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytes, err := test.properties.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(bytes) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, bytes)
+			}
+		})
+	}
+}
+
+func TestPropertiesSet(t *testing.T) {
+	properties := Properties{}
+	properties.Set("b", &SchemaProperty{Type: "string"})
+	properties.Set("a", &SchemaProperty{Type: "integer"})
+	properties.Set("b", &SchemaProperty{Type: "boolean"})
+	bytes, err := properties.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"b":{"type":"boolean"},"a":{"type":"integer"}}`; string(bytes) != expected {
+		t.Errorf("expected %s, got %s", expected, bytes)
+	}
+}
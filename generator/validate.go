@@ -0,0 +1,346 @@
+package generator
+
+import (
+	"regexp"
+
+	protovalidate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate/priv"
+	pgv "github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CelRule is a validation rule without JSON schema equivalent, carried in the "x-cel" keyword
+type CelRule struct {
+	Id         string `json:"id,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+// applyProtovalidate translates the buf.validate.field annotation of the field into keywords of the SchemaProperty
+func (g *JSONSchemaGenerator) applyProtovalidate(field *protogen.Field, propertySchema *SchemaProperty) {
+	constraints, ok := proto.GetExtension(field.Desc.Options(), protovalidate.E_Field).(*protovalidate.FieldConstraints)
+	if !ok || constraints == nil || constraints.GetSkipped() {
+		return
+	}
+	if constraints.GetRequired() {
+		propertySchema.IsRequired = true
+	}
+	g.applyFieldConstraints(constraints.ProtoReflect(), field, propertySchema)
+}
+
+// applyValidateRules translates the validate.rules annotation of protoc-gen-validate into keywords of the SchemaProperty
+func (g *JSONSchemaGenerator) applyValidateRules(field *protogen.Field, propertySchema *SchemaProperty) {
+	if disabled, ok := proto.GetExtension(field.Parent.Desc.Options(), pgv.E_Disabled).(bool); ok && disabled {
+		return
+	}
+	rules, ok := proto.GetExtension(field.Desc.Options(), pgv.E_Rules).(*pgv.FieldRules)
+	if !ok || rules == nil {
+		return
+	}
+	if rules.GetMessage().GetRequired() {
+		propertySchema.IsRequired = true
+	}
+	g.applyFieldConstraints(rules.ProtoReflect(), field, propertySchema)
+}
+
+// applyFieldConstraints translates the field constraints of protovalidate or the field rules of protoc-gen-validate into keywords of the SchemaProperty.
+// Both share the same layout: a "type" oneof holding the rules for each kind of field
+func (g *JSONSchemaGenerator) applyFieldConstraints(msg protoreflect.Message, field *protogen.Field, propertySchema *SchemaProperty) {
+	celRules := []CelRule{}
+	if constraints, ok := msg.Interface().(*protovalidate.FieldConstraints); ok {
+		for _, constraint := range constraints.GetCel() {
+			celRules = append(celRules, CelRule{Id: constraint.GetId(), Message: constraint.GetMessage(), Expression: constraint.GetExpression()})
+		}
+	}
+	if typeField := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("type")); typeField != nil {
+		rules := msg.Get(typeField).Message()
+		excludedRange := g.applyExcludedRange(rules, field, propertySchema)
+		ruleFields := rules.Descriptor().Fields()
+		for i := 0; i < ruleFields.Len(); i++ {
+			rule := ruleFields.Get(i)
+			if !rules.Has(rule) {
+				continue
+			}
+			if excludedRange && boundRules[rule.Name()] {
+				continue
+			}
+			if !g.applyValidationRule(typeField.Name(), rule.Name(), rules.Get(rule), field, propertySchema) {
+				// standard protovalidate rules declare the CEL expression implementing them
+				if ruleConstraints, ok := proto.GetExtension(rule.Options(), priv.E_Field).(*priv.FieldConstraints); ok {
+					for _, constraint := range ruleConstraints.GetCel() {
+						celRules = append(celRules, CelRule{Id: constraint.GetId(), Message: constraint.GetMessage(), Expression: constraint.GetExpression()})
+					}
+				}
+			}
+		}
+	}
+	if len(celRules) > 0 {
+		if propertySchema.Extra == nil {
+			propertySchema.Extra = make(map[string]interface{})
+		}
+		propertySchema.Extra["x-cel"] = celRules
+	}
+}
+
+// applyValidationRule translates a single validation rule into keywords of the SchemaProperty. Returns false if the rule has no JSON schema equivalent
+func (g *JSONSchemaGenerator) applyValidationRule(ruleType, rule protoreflect.Name, value protoreflect.Value, field *protogen.Field, propertySchema *SchemaProperty) bool {
+	switch ruleType {
+	case "float", "double", "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64":
+		switch rule {
+		case "const":
+			propertySchema.Const = g.ruleValue(value, field)
+		case "lt":
+			propertySchema.ExclusiveMaximum = g.ruleValue(value, field)
+		case "lte":
+			propertySchema.Maximum = g.ruleValue(value, field)
+		case "gt":
+			propertySchema.ExclusiveMinimum = g.ruleValue(value, field)
+		case "gte":
+			propertySchema.Minimum = g.ruleValue(value, field)
+		case "in":
+			propertySchema.Enum = g.ruleValue(value, field).([]interface{})
+		case "not_in":
+			g.addNot(propertySchema, &SchemaProperty{Enum: g.ruleValue(value, field).([]interface{})})
+		case "finite":
+			// JSON numbers are always finite
+		default:
+			return false
+		}
+	case "bool":
+		if rule != "const" {
+			return false
+		}
+		propertySchema.Const = value.Bool()
+	case "string":
+		return g.applyStringRule(rule, value, field, propertySchema)
+	case "bytes":
+		// bytes are base64 encoded in JSON, so only whole values can be compared
+		switch rule {
+		case "const":
+			propertySchema.Const = g.ruleValue(value, field)
+		case "in":
+			propertySchema.Enum = g.ruleValue(value, field).([]interface{})
+		default:
+			return false
+		}
+	case "enum":
+		switch rule {
+		case "const":
+			propertySchema.Const = g.ruleValue(value, field)
+		case "defined_only":
+			// enum values are already restricted to the defined names
+		case "in":
+			propertySchema.Enum = g.ruleValue(value, field).([]interface{})
+		case "not_in":
+			g.addNot(propertySchema, &SchemaProperty{Enum: g.ruleValue(value, field).([]interface{})})
+		default:
+			return false
+		}
+	case "repeated":
+		switch rule {
+		case "min_items":
+			propertySchema.MinItems = int32(value.Uint())
+		case "max_items":
+			propertySchema.MaxItems = int32(value.Uint())
+		case "unique":
+			propertySchema.UniqueItems = value.Bool()
+		case "items":
+			if propertySchema.Items == nil {
+				return false
+			}
+			g.applyFieldConstraints(value.Message(), field, propertySchema.Items)
+		default:
+			return false
+		}
+	case "map":
+		switch rule {
+		case "min_pairs":
+			propertySchema.MinProperties = int32(value.Uint())
+		case "max_pairs":
+			propertySchema.MaxProperties = int32(value.Uint())
+		case "keys":
+			propertySchema.PropertyNames = &SchemaProperty{}
+			g.applyFieldConstraints(value.Message(), field.Message.Fields[0], propertySchema.PropertyNames)
+		case "values":
+			values := &SchemaProperty{}
+			g.applyFieldConstraints(value.Message(), field.Message.Fields[1], values)
+			propertySchema.AdditionalProperties = values
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// boundRules are the names of the rules bounding numeric values
+var boundRules = map[protoreflect.Name]bool{"lt": true, "lte": true, "gt": true, "gte": true}
+
+// applyExcludedRange translates numeric bounds whose upper bound is below the lower bound. protovalidate and protoc-gen-validate then require
+// the value to be outside of the range between them, e.g. gt: 10 and lt: 0 only accept values above 10 or below 0. Returns false if the bounds
+// don't exclude a range, in which case each bound is translated on its own
+func (g *JSONSchemaGenerator) applyExcludedRange(rules protoreflect.Message, field *protogen.Field, propertySchema *SchemaProperty) bool {
+	upperRule, upper, ok := setRule(rules, "lt", "lte")
+	if !ok {
+		return false
+	}
+	lowerRule, lower, ok := setRule(rules, "gt", "gte")
+	if !ok || !lessThan(upper, lower) {
+		return false
+	}
+	below, above := &SchemaProperty{}, &SchemaProperty{}
+	if upperRule == "lt" {
+		below.ExclusiveMaximum = g.ruleValue(upper, field)
+	} else {
+		below.Maximum = g.ruleValue(upper, field)
+	}
+	if lowerRule == "gt" {
+		above.ExclusiveMinimum = g.ruleValue(lower, field)
+	} else {
+		above.Minimum = g.ruleValue(lower, field)
+	}
+	g.addAnyOf(propertySchema, below, above)
+	return true
+}
+
+// setRule returns the name and value of the first of the given rules which is set
+func setRule(rules protoreflect.Message, names ...protoreflect.Name) (protoreflect.Name, protoreflect.Value, bool) {
+	for _, name := range names {
+		if rule := rules.Descriptor().Fields().ByName(name); rule != nil && rules.Has(rule) {
+			return name, rules.Get(rule), true
+		}
+	}
+	return "", protoreflect.Value{}, false
+}
+
+// lessThan checks if the numeric value a is less than b. Values of other kinds, like the timestamps of
+// timestamp rules, are never less
+func lessThan(a, b protoreflect.Value) bool {
+	switch a := a.Interface().(type) {
+	case int32:
+		return a < b.Interface().(int32)
+	case int64:
+		return a < b.Interface().(int64)
+	case uint32:
+		return a < b.Interface().(uint32)
+	case uint64:
+		return a < b.Interface().(uint64)
+	case float32:
+		return a < b.Interface().(float32)
+	case float64:
+		return a < b.Interface().(float64)
+	default:
+		return false
+	}
+}
+
+// applyStringRule translates a single string validation rule into keywords of the SchemaProperty. Returns false if the rule has no JSON schema equivalent
+func (g *JSONSchemaGenerator) applyStringRule(rule protoreflect.Name, value protoreflect.Value, field *protogen.Field, propertySchema *SchemaProperty) bool {
+	switch rule {
+	case "const":
+		propertySchema.Const = value.String()
+	case "len":
+		propertySchema.MinLength = int32(value.Uint())
+		propertySchema.MaxLength = int32(value.Uint())
+	case "min_len":
+		propertySchema.MinLength = int32(value.Uint())
+	case "max_len":
+		propertySchema.MaxLength = int32(value.Uint())
+	case "pattern":
+		g.addPattern(propertySchema, value.String())
+	case "prefix":
+		g.addPattern(propertySchema, "^"+regexp.QuoteMeta(value.String()))
+	case "suffix":
+		g.addPattern(propertySchema, regexp.QuoteMeta(value.String())+"$")
+	case "contains":
+		g.addPattern(propertySchema, regexp.QuoteMeta(value.String()))
+	case "not_contains":
+		g.addNot(propertySchema, &SchemaProperty{Pattern: regexp.QuoteMeta(value.String())})
+	case "in":
+		propertySchema.Enum = g.ruleValue(value, field).([]interface{})
+	case "not_in":
+		g.addNot(propertySchema, &SchemaProperty{Enum: g.ruleValue(value, field).([]interface{})})
+	case "email", "hostname", "ipv4", "ipv6", "uri", "uuid":
+		if value.Bool() {
+			g.addFormat(propertySchema, string(rule))
+		}
+	case "uri_ref":
+		if value.Bool() {
+			g.addFormat(propertySchema, "uri-reference")
+		}
+	case "ip":
+		if value.Bool() {
+			g.addAnyOf(propertySchema, &SchemaProperty{Format: "ipv4"}, &SchemaProperty{Format: "ipv6"})
+		}
+	case "address":
+		if value.Bool() {
+			g.addAnyOf(propertySchema, &SchemaProperty{Format: "hostname"}, &SchemaProperty{Format: "ipv4"}, &SchemaProperty{Format: "ipv6"})
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// ruleValue converts the value of a validation rule into its JSON representation
+func (g *JSONSchemaGenerator) ruleValue(value protoreflect.Value, field *protogen.Field) interface{} {
+	switch v := value.Interface().(type) {
+	case protoreflect.List:
+		values := []interface{}{}
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, g.ruleValue(v.Get(i), field))
+		}
+		return values
+	case int32:
+		// enum rules are expressed with numbers but enums are serialized with their names
+		if field.Enum != nil {
+			if enumValue := field.Enum.Desc.Values().ByNumber(protoreflect.EnumNumber(v)); enumValue != nil {
+				return string(enumValue.Name())
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// addPattern sets the pattern of the SchemaProperty, or adds another pattern to match if one is already set
+func (g *JSONSchemaGenerator) addPattern(propertySchema *SchemaProperty, pattern string) {
+	if propertySchema.Pattern == "" {
+		propertySchema.Pattern = pattern
+		return
+	}
+	propertySchema.AllOf = append(propertySchema.AllOf, &SchemaProperty{Pattern: pattern})
+}
+
+// addFormat sets the format of the SchemaProperty, or adds another format to match if one is already set
+func (g *JSONSchemaGenerator) addFormat(propertySchema *SchemaProperty, format string) {
+	if propertySchema.Format == "" {
+		propertySchema.Format = format
+		return
+	}
+	if propertySchema.Format != format {
+		propertySchema.AllOf = append(propertySchema.AllOf, &SchemaProperty{Format: format})
+	}
+}
+
+// addAnyOf sets the alternatives the SchemaProperty must match, or adds other alternatives if they are already set
+func (g *JSONSchemaGenerator) addAnyOf(propertySchema *SchemaProperty, anyOf ...*SchemaProperty) {
+	if len(propertySchema.AnyOf) == 0 {
+		propertySchema.AnyOf = anyOf
+		return
+	}
+	propertySchema.AllOf = append(propertySchema.AllOf, &SchemaProperty{AnyOf: anyOf})
+}
+
+// addNot sets the schema the SchemaProperty must not match, or adds another one if it is already set
+func (g *JSONSchemaGenerator) addNot(propertySchema *SchemaProperty, not *SchemaProperty) {
+	if propertySchema.Not == nil {
+		propertySchema.Not = not
+		return
+	}
+	propertySchema.AllOf = append(propertySchema.AllOf, &SchemaProperty{Not: not})
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats supported by the output_format parameter
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatYML  = "yml"
+)
+
+// descriptionWidth is the length above which descriptions are written as folded block scalars
+const descriptionWidth = 80
+
+// checkOutputFormat checks that the configured output format is supported
+func (g *JSONSchemaGenerator) checkOutputFormat() error {
+	switch *g.cfg.OutputFormat {
+	case FormatJSON, FormatYAML, FormatYML:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q. Use %q, %q or %q", *g.cfg.OutputFormat, FormatJSON, FormatYAML, FormatYML)
+	}
+}
+
+// fileExtension returns the extension of generated files
+func (g *JSONSchemaGenerator) fileExtension() string {
+	return "." + *g.cfg.OutputFormat
+}
+
+// writeFile writes the JSON serialized content into the generated file with the given name
+func (g *JSONSchemaGenerator) writeFile(name string, content []byte) error {
+	if *g.cfg.OutputFormat != FormatJSON {
+		var err error
+		if content, err = toYAML(content); err != nil {
+			return fmt.Errorf("failed to write %s as YAML: %w", name, err)
+		}
+	}
+	outputFile := g.plugin.NewGeneratedFile(name, "")
+	_, err := outputFile.Write(content)
+	return err
+}
+
+// toYAML converts JSON into YAML. JSON is valid YAML, so parsing it into nodes keeps the order of the keys
+func toYAML(content []byte) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	setBlockStyle(&document)
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setBlockStyle replaces the flow style of the parsed JSON with the block style, and writes long descriptions as block scalars
+func setBlockStyle(node *yaml.Node) {
+	node.Style = 0
+	// YAML 1.1 parsers, still common in Kubernetes tooling, read strings like "yes" or "off" as booleans
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && isYAML11Bool(node.Value) {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	for i, child := range node.Content {
+		setBlockStyle(child)
+		isValue := node.Kind == yaml.MappingNode && i%2 == 1
+		if !isValue || node.Content[i-1].Value != "description" || child.Kind != yaml.ScalarNode {
+			continue
+		}
+		if strings.Contains(child.Value, "\n") {
+			child.Style = yaml.LiteralStyle
+		} else if len(child.Value) > descriptionWidth {
+			child.Style = yaml.FoldedStyle
+		}
+	}
+}
+
+// isYAML11Bool checks if the string is a boolean in YAML 1.1
+func isYAML11Bool(value string) bool {
+	switch value {
+	case "y", "Y", "yes", "Yes", "YES", "n", "N", "no", "No", "NO",
+		"on", "On", "ON", "off", "Off", "OFF":
+		return true
+	default:
+		return false
+	}
+}
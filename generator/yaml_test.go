@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestToYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{name: "key order", json: `{"type":"object","required":["b","a"],"title":"T"}`, expected: "type: object\nrequired:\n  - b\n  - a\ntitle: T\n"},
+		{name: "nested", json: `{"properties":{"a":{"type":"string"}}}`, expected: "properties:\n  a:\n    type: string\n"},
+		{name: "empty collections", json: `{"paths":{},"enum":[]}`, expected: "paths: {}\nenum: []\n"},
+		{name: "yaml 1.1 booleans", json: `{"enum":["yes","off","true"]}`, expected: "enum:\n  - \"yes\"\n  - \"off\"\n  - \"true\"\n"},
+		{name: "numbers", json: `{"minimum":-1.5,"maxLength":10}`, expected: "minimum: -1.5\nmaxLength: 10\n"},
+		{name: "multi-line description", json: `{"description":"first\nsecond"}`, expected: "description: |-\n  first\n  second\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content, err := toYAML([]byte(test.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, content)
+			}
+		})
+	}
+}
+
+func TestSetBlockStyle(t *testing.T) {
+	long := strings.Repeat("word ", 20)
+	tests := []struct {
+		name     string
+		json     string
+		path     []string
+		expected yaml.Style
+	}{
+		{name: "mapping", json: `{"a":{"b":1}}`, path: []string{"a"}, expected: 0},
+		{name: "sequence", json: `{"a":[1,2]}`, path: []string{"a"}, expected: 0},
+		{name: "string", json: `{"a":"b"}`, path: []string{"a"}, expected: 0},
+		{name: "yaml 1.1 boolean", json: `{"a":"on"}`, path: []string{"a"}, expected: yaml.DoubleQuotedStyle},
+		{name: "short description", json: `{"description":"short"}`, path: []string{"description"}, expected: 0},
+		{name: "long description", json: `{"description":"` + long + `"}`, path: []string{"description"}, expected: yaml.FoldedStyle},
+		{name: "multi-line description", json: `{"description":"a\nb"}`, path: []string{"description"}, expected: yaml.LiteralStyle},
+		{name: "long value of another keyword", json: `{"title":"` + long + `"}`, path: []string{"title"}, expected: 0},
+		{name: "property named description", json: `{"properties":{"description":{"type":"string"}}}`, path: []string{"properties", "description"}, expected: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(test.json), &document); err != nil {
+				t.Fatal(err)
+			}
+			setBlockStyle(&document)
+			node := document.Content[0]
+			for _, key := range test.path {
+				var value *yaml.Node
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == key {
+						value = node.Content[i+1]
+					}
+				}
+				if value == nil {
+					t.Fatalf("no key %s", key)
+				}
+				node = value
+			}
+			if node.Style != test.expected {
+				t.Errorf("expected style %v, got %v", test.expected, node.Style)
+			}
+		})
+	}
+}
@@ -2,4 +2,18 @@ module github.com/TheRebelOfBabylon/protoc-gen-jsonschema
 
 go 1.20
 
-require google.golang.org/protobuf v1.31.0 // indirect
+require (
+	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.31.0-20230802163732-1c33ebd9ecfa.1
+	github.com/envoyproxy/protoc-gen-validate v1.0.2
+	github.com/google/gnostic-models v0.6.9-0.20230804172637-c7be7c783f49
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.18.1
+	google.golang.org/genproto/googleapis/api v0.0.0-20231106174013-bbf56f31fb17
+	google.golang.org/protobuf v1.31.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/kr/text v0.2.0 // indirect
+	github.com/rogpeppe/go-internal v1.9.0 // indirect
+	google.golang.org/genproto v0.0.0-20231030173426-d783a09b4405 // indirect
+)
@@ -14,6 +14,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -25,6 +26,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DefinitionsNaming is the strategy used to name definitions and the references to them
+type DefinitionsNaming int32
+
+const (
+	// Definitions are named after the message name, e.g. "Bar"
+	DefinitionsNaming_DEFINITIONS_NAMING_NAME DefinitionsNaming = 0
+	// Definitions are named after the fully qualified message name, e.g. "foo.v1.Bar"
+	DefinitionsNaming_DEFINITIONS_NAMING_FULL_NAME DefinitionsNaming = 1
+)
+
+// Enum value maps for DefinitionsNaming.
+var (
+	DefinitionsNaming_name = map[int32]string{
+		0: "DEFINITIONS_NAMING_NAME",
+		1: "DEFINITIONS_NAMING_FULL_NAME",
+	}
+	DefinitionsNaming_value = map[string]int32{
+		"DEFINITIONS_NAMING_NAME":      0,
+		"DEFINITIONS_NAMING_FULL_NAME": 1,
+	}
+)
+
+func (x DefinitionsNaming) Enum() *DefinitionsNaming {
+	p := new(DefinitionsNaming)
+	*p = x
+	return p
+}
+
+func (x DefinitionsNaming) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DefinitionsNaming) Descriptor() protoreflect.EnumDescriptor {
+	return file_options_proto_enumTypes[0].Descriptor()
+}
+
+func (DefinitionsNaming) Type() protoreflect.EnumType {
+	return &file_options_proto_enumTypes[0]
+}
+
+func (x DefinitionsNaming) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DefinitionsNaming.Descriptor instead.
+func (DefinitionsNaming) EnumDescriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{0}
+}
+
 // Custom FieldOptions
 type FieldOptions struct {
 	state         protoimpl.MessageState
@@ -47,6 +97,16 @@ type FieldOptions struct {
 	MinItems int32 `protobuf:"varint,7,opt,name=min_items,json=minItems,proto3" json:"min_items,omitempty"`
 	// Fields tagged with this will constrain strings using the "format" keyword in generated schemas
 	Format string `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
+	// Fields tagged with this will merge the given keywords into generated schemas
+	//
+	// Types that are assignable to ExtraKeywords:
+	//	*FieldOptions_Extra
+	//	*FieldOptions_ExtraStruct
+	ExtraKeywords isFieldOptions_ExtraKeywords `protobuf_oneof:"extra_keywords"`
+	// Fields tagged with this will be marked as "readOnly" in generated schemas
+	ReadOnly bool `protobuf:"varint,10,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// Fields tagged with this will be marked as "writeOnly" in generated schemas
+	WriteOnly bool `protobuf:"varint,11,opt,name=write_only,json=writeOnly,proto3" json:"write_only,omitempty"`
 }
 
 func (x *FieldOptions) Reset() {
@@ -137,6 +197,59 @@ func (x *FieldOptions) GetFormat() string {
 	return ""
 }
 
+func (m *FieldOptions) GetExtraKeywords() isFieldOptions_ExtraKeywords {
+	if m != nil {
+		return m.ExtraKeywords
+	}
+	return nil
+}
+
+func (x *FieldOptions) GetExtra() string {
+	if x, ok := x.GetExtraKeywords().(*FieldOptions_Extra); ok {
+		return x.Extra
+	}
+	return ""
+}
+
+func (x *FieldOptions) GetExtraStruct() *structpb.Struct {
+	if x, ok := x.GetExtraKeywords().(*FieldOptions_ExtraStruct); ok {
+		return x.ExtraStruct
+	}
+	return nil
+}
+
+func (x *FieldOptions) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *FieldOptions) GetWriteOnly() bool {
+	if x != nil {
+		return x.WriteOnly
+	}
+	return false
+}
+
+type isFieldOptions_ExtraKeywords interface {
+	isFieldOptions_ExtraKeywords()
+}
+
+type FieldOptions_Extra struct {
+	// Keywords given as the text of a JSON object
+	Extra string `protobuf:"bytes,9,opt,name=extra,proto3,oneof"`
+}
+
+type FieldOptions_ExtraStruct struct {
+	// Keywords given as a struct
+	ExtraStruct *structpb.Struct `protobuf:"bytes,12,opt,name=extra_struct,json=extraStruct,proto3,oneof"`
+}
+
+func (*FieldOptions_Extra) isFieldOptions_ExtraKeywords() {}
+
+func (*FieldOptions_ExtraStruct) isFieldOptions_ExtraKeywords() {}
+
 // Custom MessageOptions
 type MessageOptions struct {
 	state         protoimpl.MessageState
@@ -149,6 +262,26 @@ type MessageOptions struct {
 	AllFieldsRequired bool `protobuf:"varint,2,opt,name=all_fields_required,json=allFieldsRequired,proto3" json:"all_fields_required,omitempty"`
 	// Messages tagged with this will populate the id field with provided value. Default value is filename with json extension
 	Id string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// Messages tagged with this will set the "additionalProperties" keyword in generated schemas, overriding the strict parameter
+	AdditionalProperties *AdditionalProperties `protobuf:"bytes,4,opt,name=additional_properties,json=additionalProperties,proto3" json:"additional_properties,omitempty"`
+	// Messages tagged with this will constrain objects using the "minProperties" keyword in generated schemas
+	MinProperties int32 `protobuf:"varint,5,opt,name=min_properties,json=minProperties,proto3" json:"min_properties,omitempty"`
+	// Messages tagged with this will constrain objects using the "maxProperties" keyword in generated schemas
+	MaxProperties int32 `protobuf:"varint,6,opt,name=max_properties,json=maxProperties,proto3" json:"max_properties,omitempty"`
+	// Messages tagged with this will add the conditions using the "if", "then" and "else" keywords in generated schemas
+	Conditions []*Condition `protobuf:"bytes,7,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// Messages tagged with this will require the listed fields whenever the given field is present, using the "dependencies" keyword in generated schemas
+	DependentRequired []*DependentRequired `protobuf:"bytes,8,rep,name=dependent_required,json=dependentRequired,proto3" json:"dependent_required,omitempty"`
+	// Messages tagged with this will allow at most one field of each group to be present in generated schemas
+	MutuallyExclusive []*FieldGroup `protobuf:"bytes,9,rep,name=mutually_exclusive,json=mutuallyExclusive,proto3" json:"mutually_exclusive,omitempty"`
+	// Messages tagged with this will require at least one field of each group to be present in generated schemas
+	AtLeastOneOf []*FieldGroup `protobuf:"bytes,10,rep,name=at_least_one_of,json=atLeastOneOf,proto3" json:"at_least_one_of,omitempty"`
+	// Messages tagged with this will merge the given keywords into generated schemas
+	//
+	// Types that are assignable to ExtraKeywords:
+	//	*MessageOptions_Extra
+	//	*MessageOptions_ExtraStruct
+	ExtraKeywords isMessageOptions_ExtraKeywords `protobuf_oneof:"extra_keywords"`
 }
 
 func (x *MessageOptions) Reset() {
@@ -204,6 +337,512 @@ func (x *MessageOptions) GetId() string {
 	return ""
 }
 
+func (x *MessageOptions) GetAdditionalProperties() *AdditionalProperties {
+	if x != nil {
+		return x.AdditionalProperties
+	}
+	return nil
+}
+
+func (x *MessageOptions) GetMinProperties() int32 {
+	if x != nil {
+		return x.MinProperties
+	}
+	return 0
+}
+
+func (x *MessageOptions) GetMaxProperties() int32 {
+	if x != nil {
+		return x.MaxProperties
+	}
+	return 0
+}
+
+func (x *MessageOptions) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *MessageOptions) GetDependentRequired() []*DependentRequired {
+	if x != nil {
+		return x.DependentRequired
+	}
+	return nil
+}
+
+func (x *MessageOptions) GetMutuallyExclusive() []*FieldGroup {
+	if x != nil {
+		return x.MutuallyExclusive
+	}
+	return nil
+}
+
+func (x *MessageOptions) GetAtLeastOneOf() []*FieldGroup {
+	if x != nil {
+		return x.AtLeastOneOf
+	}
+	return nil
+}
+
+func (m *MessageOptions) GetExtraKeywords() isMessageOptions_ExtraKeywords {
+	if m != nil {
+		return m.ExtraKeywords
+	}
+	return nil
+}
+
+func (x *MessageOptions) GetExtra() string {
+	if x, ok := x.GetExtraKeywords().(*MessageOptions_Extra); ok {
+		return x.Extra
+	}
+	return ""
+}
+
+func (x *MessageOptions) GetExtraStruct() *structpb.Struct {
+	if x, ok := x.GetExtraKeywords().(*MessageOptions_ExtraStruct); ok {
+		return x.ExtraStruct
+	}
+	return nil
+}
+
+type isMessageOptions_ExtraKeywords interface {
+	isMessageOptions_ExtraKeywords()
+}
+
+type MessageOptions_Extra struct {
+	// Keywords given as the text of a JSON object
+	Extra string `protobuf:"bytes,11,opt,name=extra,proto3,oneof"`
+}
+
+type MessageOptions_ExtraStruct struct {
+	// Keywords given as a struct
+	ExtraStruct *structpb.Struct `protobuf:"bytes,12,opt,name=extra_struct,json=extraStruct,proto3,oneof"`
+}
+
+func (*MessageOptions_Extra) isMessageOptions_ExtraKeywords() {}
+
+func (*MessageOptions_ExtraStruct) isMessageOptions_ExtraKeywords() {}
+
+// Condition requires fields depending on the value of another field
+type Condition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the field whose value is checked
+	IfField string `protobuf:"bytes,1,opt,name=if_field,json=ifField,proto3" json:"if_field,omitempty"`
+	// Value the field must be equal to for the condition to hold
+	IfValue string `protobuf:"bytes,2,opt,name=if_value,json=ifValue,proto3" json:"if_value,omitempty"`
+	// Names of the fields that are required when the condition holds
+	ThenRequired []string `protobuf:"bytes,3,rep,name=then_required,json=thenRequired,proto3" json:"then_required,omitempty"`
+	// Names of the fields that are required when the condition does not hold
+	ElseRequired []string `protobuf:"bytes,4,rep,name=else_required,json=elseRequired,proto3" json:"else_required,omitempty"`
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_options_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Condition) GetIfField() string {
+	if x != nil {
+		return x.IfField
+	}
+	return ""
+}
+
+func (x *Condition) GetIfValue() string {
+	if x != nil {
+		return x.IfValue
+	}
+	return ""
+}
+
+func (x *Condition) GetThenRequired() []string {
+	if x != nil {
+		return x.ThenRequired
+	}
+	return nil
+}
+
+func (x *Condition) GetElseRequired() []string {
+	if x != nil {
+		return x.ElseRequired
+	}
+	return nil
+}
+
+// Custom FileOptions
+type FileOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Files tagged with this will not be processed
+	Ignore bool `protobuf:"varint,1,opt,name=ignore,proto3" json:"ignore,omitempty"`
+	// Files tagged with this will only generate schemas for messages annotated with message_options
+	OptIn bool `protobuf:"varint,2,opt,name=opt_in,json=optIn,proto3" json:"opt_in,omitempty"`
+	// Files tagged with this will prefix the default id of every message with the given base URI, which must end with a slash
+	IdBase string `protobuf:"bytes,3,opt,name=id_base,json=idBase,proto3" json:"id_base,omitempty"`
+	// Files tagged with this will name the definitions of their messages using the given strategy
+	DefinitionsNaming DefinitionsNaming `protobuf:"varint,4,opt,name=definitions_naming,json=definitionsNaming,proto3,enum=protoc.gen.jsonschema.DefinitionsNaming" json:"definitions_naming,omitempty"`
+	// Files tagged with this will use the given value instead of the strict parameter for all of their messages
+	Strict *bool `protobuf:"varint,5,opt,name=strict,proto3,oneof" json:"strict,omitempty"`
+	// Files tagged with this will prefix the title of every message with the given value
+	TitlePrefix string `protobuf:"bytes,6,opt,name=title_prefix,json=titlePrefix,proto3" json:"title_prefix,omitempty"`
+}
+
+func (x *FileOptions) Reset() {
+	*x = FileOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileOptions) ProtoMessage() {}
+
+func (x *FileOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_options_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileOptions.ProtoReflect.Descriptor instead.
+func (*FileOptions) Descriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FileOptions) GetIgnore() bool {
+	if x != nil {
+		return x.Ignore
+	}
+	return false
+}
+
+func (x *FileOptions) GetOptIn() bool {
+	if x != nil {
+		return x.OptIn
+	}
+	return false
+}
+
+func (x *FileOptions) GetIdBase() string {
+	if x != nil {
+		return x.IdBase
+	}
+	return ""
+}
+
+func (x *FileOptions) GetDefinitionsNaming() DefinitionsNaming {
+	if x != nil {
+		return x.DefinitionsNaming
+	}
+	return DefinitionsNaming_DEFINITIONS_NAMING_NAME
+}
+
+func (x *FileOptions) GetStrict() bool {
+	if x != nil && x.Strict != nil {
+		return *x.Strict
+	}
+	return false
+}
+
+func (x *FileOptions) GetTitlePrefix() string {
+	if x != nil {
+		return x.TitlePrefix
+	}
+	return ""
+}
+
+// Custom MethodOptions
+type MethodOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Methods tagged with this will not be processed
+	Ignore bool `protobuf:"varint,1,opt,name=ignore,proto3" json:"ignore,omitempty"`
+	// Methods tagged with this will populate the id field of their schema with provided value. Default value is service and method name with json extension
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *MethodOptions) Reset() {
+	*x = MethodOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MethodOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodOptions) ProtoMessage() {}
+
+func (x *MethodOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_options_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodOptions.ProtoReflect.Descriptor instead.
+func (*MethodOptions) Descriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MethodOptions) GetIgnore() bool {
+	if x != nil {
+		return x.Ignore
+	}
+	return false
+}
+
+func (x *MethodOptions) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DependentRequired requires fields whenever another field is present
+type DependentRequired struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the field whose presence is checked
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Names of the fields that are required when the field is present
+	Required []string `protobuf:"bytes,2,rep,name=required,proto3" json:"required,omitempty"`
+}
+
+func (x *DependentRequired) Reset() {
+	*x = DependentRequired{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DependentRequired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependentRequired) ProtoMessage() {}
+
+func (x *DependentRequired) ProtoReflect() protoreflect.Message {
+	mi := &file_options_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependentRequired.ProtoReflect.Descriptor instead.
+func (*DependentRequired) Descriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DependentRequired) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DependentRequired) GetRequired() []string {
+	if x != nil {
+		return x.Required
+	}
+	return nil
+}
+
+// FieldGroup is a group of field names
+type FieldGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Names of the fields in the group
+	Fields []string `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *FieldGroup) Reset() {
+	*x = FieldGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldGroup) ProtoMessage() {}
+
+func (x *FieldGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_options_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldGroup.ProtoReflect.Descriptor instead.
+func (*FieldGroup) Descriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FieldGroup) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// AdditionalProperties controls which properties not declared in the message are accepted
+type AdditionalProperties struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Value:
+	//	*AdditionalProperties_Allow
+	//	*AdditionalProperties_Ref
+	Value isAdditionalProperties_Value `protobuf_oneof:"value"`
+}
+
+func (x *AdditionalProperties) Reset() {
+	*x = AdditionalProperties{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_options_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdditionalProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdditionalProperties) ProtoMessage() {}
+
+func (x *AdditionalProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_options_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdditionalProperties.ProtoReflect.Descriptor instead.
+func (*AdditionalProperties) Descriptor() ([]byte, []int) {
+	return file_options_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *AdditionalProperties) GetValue() isAdditionalProperties_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *AdditionalProperties) GetAllow() bool {
+	if x, ok := x.GetValue().(*AdditionalProperties_Allow); ok {
+		return x.Allow
+	}
+	return false
+}
+
+func (x *AdditionalProperties) GetRef() string {
+	if x, ok := x.GetValue().(*AdditionalProperties_Ref); ok {
+		return x.Ref
+	}
+	return ""
+}
+
+type isAdditionalProperties_Value interface {
+	isAdditionalProperties_Value()
+}
+
+type AdditionalProperties_Allow struct {
+	// If true, undeclared properties are allowed. If false, they are forbidden
+	Allow bool `protobuf:"varint,1,opt,name=allow,proto3,oneof"`
+}
+
+type AdditionalProperties_Ref struct {
+	// Undeclared properties must validate against the schema at the given reference
+	Ref string `protobuf:"bytes,2,opt,name=ref,proto3,oneof"`
+}
+
+func (*AdditionalProperties_Allow) isAdditionalProperties_Value() {}
+
+func (*AdditionalProperties_Ref) isAdditionalProperties_Value() {}
+
 var file_options_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
@@ -213,6 +852,14 @@ var file_options_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,1125,opt,name=field_options",
 		Filename:      "options.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*FileOptions)(nil),
+		Field:         1126,
+		Name:          "protoc.gen.jsonschema.file_options",
+		Tag:           "bytes,1126,opt,name=file_options",
+		Filename:      "options.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
 		ExtensionType: (*MessageOptions)(nil),
@@ -221,6 +868,14 @@ var file_options_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,1127,opt,name=message_options",
 		Filename:      "options.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*MethodOptions)(nil),
+		Field:         1128,
+		Name:          "protoc.gen.jsonschema.method_options",
+		Tag:           "bytes,1128,opt,name=method_options",
+		Filename:      "options.proto",
+	},
 }
 
 // Extension fields to descriptorpb.FieldOptions.
@@ -229,10 +884,22 @@ var (
 	E_FieldOptions = &file_options_proto_extTypes[0]
 )
 
+// Extension fields to descriptorpb.FileOptions.
+var (
+	// optional protoc.gen.jsonschema.FileOptions file_options = 1126;
+	E_FileOptions = &file_options_proto_extTypes[1]
+)
+
 // Extension fields to descriptorpb.MessageOptions.
 var (
 	// optional protoc.gen.jsonschema.MessageOptions message_options = 1127;
-	E_MessageOptions = &file_options_proto_extTypes[1]
+	E_MessageOptions = &file_options_proto_extTypes[2]
+)
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional protoc.gen.jsonschema.MethodOptions method_options = 1128;
+	E_MethodOptions = &file_options_proto_extTypes[3]
 )
 
 var File_options_proto protoreflect.FileDescriptor
@@ -242,45 +909,153 @@ var file_options_proto_rawDesc = []byte{
 	0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e,
 	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1, 0x01, 0x0a, 0x0c, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e,
-	0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1d, 0x0a,
-	0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a,
-	0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x70,
-	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
-	0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x69,
-	0x74, 0x65, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x49,
-	0x74, 0x65, 0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x68, 0x0a, 0x0e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x5f, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x3a, 0x68, 0x0a, 0x0d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f,
-	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe5, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x85, 0x03, 0x0a, 0x0c, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61,
+	0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x49, 0x74, 0x65,
+	0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x78,
+	0x74, 0x72, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x78, 0x74,
+	0x72, 0x61, 0x12, 0x3c, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x72, 0x61, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1d, 0x0a,
+	0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x77, 0x72, 0x69, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x42, 0x10, 0x0a, 0x0e,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xb7,
+	0x05, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6c, 0x6c,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x60, 0x0a, 0x15, 0x61, 0x64, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x65,
+	0x72, 0x74, 0x69, 0x65, 0x73, 0x52, 0x14, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d,
+	0x69, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x50,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0a, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x3a, 0x70, 0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe7, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x54, 0x68, 0x65, 0x52, 0x65, 0x62, 0x65, 0x6c, 0x4f, 0x66, 0x42, 0x61, 0x62, 0x79, 0x6c,
-	0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6a, 0x73,
-	0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x57, 0x0a, 0x12, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x52, 0x11, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x12, 0x50, 0x0a, 0x12, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x6c, 0x79,
+	0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73,
+	0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x52, 0x11, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x6c, 0x79, 0x45, 0x78, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x48, 0x0a, 0x0f, 0x61, 0x74, 0x5f, 0x6c, 0x65, 0x61,
+	0x73, 0x74, 0x5f, 0x6f, 0x6e, 0x65, 0x5f, 0x6f, 0x66, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f,
+	0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x52, 0x0c, 0x61, 0x74, 0x4c, 0x65, 0x61, 0x73, 0x74, 0x4f, 0x6e, 0x65, 0x4f, 0x66,
+	0x12, 0x16, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x12, 0x3c, 0x0a, 0x0c, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x5f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x72, 0x61,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f,
+	0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x66, 0x5f, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x66, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x66, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x66, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x68, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x68, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6c, 0x73, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6c, 0x73, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22, 0xf9, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x6c, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x15,
+	0x0a, 0x06, 0x6f, 0x70, 0x74, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x6f, 0x70, 0x74, 0x49, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x64, 0x5f, 0x62, 0x61, 0x73, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x64, 0x42, 0x61, 0x73, 0x65, 0x12, 0x57,
+	0x0a, 0x12, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6e, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x4e, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x52, 0x11, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x5f, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x72, 0x69,
+	0x63, 0x74, 0x22, 0x37, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x45, 0x0a, 0x11, 0x44,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x22, 0x24, 0x0a, 0x0a, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x4b, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x00, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x72, 0x65, 0x66, 0x42, 0x07, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a, 0x52, 0x0a, 0x11, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x45,
+	0x46, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x5f, 0x4e, 0x41, 0x4d, 0x49, 0x4e, 0x47,
+	0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x44, 0x45, 0x46, 0x49, 0x4e,
+	0x49, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x5f, 0x4e, 0x41, 0x4d, 0x49, 0x4e, 0x47, 0x5f, 0x46, 0x55,
+	0x4c, 0x4c, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x01, 0x3a, 0x68, 0x0a, 0x0d, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe5, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73,
+	0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x3a, 0x64, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0xe6, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x2e, 0x67, 0x65, 0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x66, 0x69,
+	0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x3a, 0x70, 0x0a, 0x0f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe7, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65,
+	0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0e, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x3a, 0x6c, 0x0a, 0x0e, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xe8, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2e, 0x67, 0x65,
+	0x6e, 0x2e, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54, 0x68, 0x65, 0x52, 0x65, 0x62, 0x65, 0x6c,
+	0x4f, 0x66, 0x42, 0x61, 0x62, 0x79, 0x6c, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -295,23 +1070,46 @@ func file_options_proto_rawDescGZIP() []byte {
 	return file_options_proto_rawDescData
 }
 
-var file_options_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_options_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_options_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_options_proto_goTypes = []interface{}{
-	(*FieldOptions)(nil),                // 0: protoc.gen.jsonschema.FieldOptions
-	(*MessageOptions)(nil),              // 1: protoc.gen.jsonschema.MessageOptions
-	(*descriptorpb.FieldOptions)(nil),   // 2: google.protobuf.FieldOptions
-	(*descriptorpb.MessageOptions)(nil), // 3: google.protobuf.MessageOptions
+	(DefinitionsNaming)(0),              // 0: protoc.gen.jsonschema.DefinitionsNaming
+	(*FieldOptions)(nil),                // 1: protoc.gen.jsonschema.FieldOptions
+	(*MessageOptions)(nil),              // 2: protoc.gen.jsonschema.MessageOptions
+	(*Condition)(nil),                   // 3: protoc.gen.jsonschema.Condition
+	(*FileOptions)(nil),                 // 4: protoc.gen.jsonschema.FileOptions
+	(*MethodOptions)(nil),               // 5: protoc.gen.jsonschema.MethodOptions
+	(*DependentRequired)(nil),           // 6: protoc.gen.jsonschema.DependentRequired
+	(*FieldGroup)(nil),                  // 7: protoc.gen.jsonschema.FieldGroup
+	(*AdditionalProperties)(nil),        // 8: protoc.gen.jsonschema.AdditionalProperties
+	(*structpb.Struct)(nil),             // 9: google.protobuf.Struct
+	(*descriptorpb.FieldOptions)(nil),   // 10: google.protobuf.FieldOptions
+	(*descriptorpb.FileOptions)(nil),    // 11: google.protobuf.FileOptions
+	(*descriptorpb.MessageOptions)(nil), // 12: google.protobuf.MessageOptions
+	(*descriptorpb.MethodOptions)(nil),  // 13: google.protobuf.MethodOptions
 }
 var file_options_proto_depIdxs = []int32{
-	2, // 0: protoc.gen.jsonschema.field_options:extendee -> google.protobuf.FieldOptions
-	3, // 1: protoc.gen.jsonschema.message_options:extendee -> google.protobuf.MessageOptions
-	0, // 2: protoc.gen.jsonschema.field_options:type_name -> protoc.gen.jsonschema.FieldOptions
-	1, // 3: protoc.gen.jsonschema.message_options:type_name -> protoc.gen.jsonschema.MessageOptions
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	2, // [2:4] is the sub-list for extension type_name
-	0, // [0:2] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	9,  // 0: protoc.gen.jsonschema.FieldOptions.extra_struct:type_name -> google.protobuf.Struct
+	8,  // 1: protoc.gen.jsonschema.MessageOptions.additional_properties:type_name -> protoc.gen.jsonschema.AdditionalProperties
+	3,  // 2: protoc.gen.jsonschema.MessageOptions.conditions:type_name -> protoc.gen.jsonschema.Condition
+	6,  // 3: protoc.gen.jsonschema.MessageOptions.dependent_required:type_name -> protoc.gen.jsonschema.DependentRequired
+	7,  // 4: protoc.gen.jsonschema.MessageOptions.mutually_exclusive:type_name -> protoc.gen.jsonschema.FieldGroup
+	7,  // 5: protoc.gen.jsonschema.MessageOptions.at_least_one_of:type_name -> protoc.gen.jsonschema.FieldGroup
+	9,  // 6: protoc.gen.jsonschema.MessageOptions.extra_struct:type_name -> google.protobuf.Struct
+	0,  // 7: protoc.gen.jsonschema.FileOptions.definitions_naming:type_name -> protoc.gen.jsonschema.DefinitionsNaming
+	10, // 8: protoc.gen.jsonschema.field_options:extendee -> google.protobuf.FieldOptions
+	11, // 9: protoc.gen.jsonschema.file_options:extendee -> google.protobuf.FileOptions
+	12, // 10: protoc.gen.jsonschema.message_options:extendee -> google.protobuf.MessageOptions
+	13, // 11: protoc.gen.jsonschema.method_options:extendee -> google.protobuf.MethodOptions
+	1,  // 12: protoc.gen.jsonschema.field_options:type_name -> protoc.gen.jsonschema.FieldOptions
+	4,  // 13: protoc.gen.jsonschema.file_options:type_name -> protoc.gen.jsonschema.FileOptions
+	2,  // 14: protoc.gen.jsonschema.message_options:type_name -> protoc.gen.jsonschema.MessageOptions
+	5,  // 15: protoc.gen.jsonschema.method_options:type_name -> protoc.gen.jsonschema.MethodOptions
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	12, // [12:16] is the sub-list for extension type_name
+	8,  // [8:12] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_options_proto_init() }
@@ -344,19 +1142,105 @@ func file_options_proto_init() {
 				return nil
 			}
 		}
+		file_options_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Condition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MethodOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DependentRequired); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_options_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdditionalProperties); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_options_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*FieldOptions_Extra)(nil),
+		(*FieldOptions_ExtraStruct)(nil),
+	}
+	file_options_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*MessageOptions_Extra)(nil),
+		(*MessageOptions_ExtraStruct)(nil),
+	}
+	file_options_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_options_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*AdditionalProperties_Allow)(nil),
+		(*AdditionalProperties_Ref)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_options_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
-			NumExtensions: 2,
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 4,
 			NumServices:   0,
 		},
 		GoTypes:           file_options_proto_goTypes,
 		DependencyIndexes: file_options_proto_depIdxs,
+		EnumInfos:         file_options_proto_enumTypes,
 		MessageInfos:      file_options_proto_msgTypes,
 		ExtensionInfos:    file_options_proto_extTypes,
 	}.Build()